@@ -0,0 +1,158 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Peter Vrba
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package jayson
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// Batch is a response of bulk endpoints that reports result of every item.
+// Every entry is rendered the same way as it would be rendered by Response or Error (with registered extensions),
+// status of the batch is chosen by policy:
+//   - all entries succeeded: success status (Settings.DefaultResponseStatus by default)
+//   - all entries failed with the same status: that status
+//   - otherwise: 207 Multi-Status
+type Batch struct {
+	entries []batchEntry
+	success int
+}
+
+// batchEntry is single entry of batch (object or error)
+type batchEntry struct {
+	value any
+	err   error
+	ext   []Extension
+}
+
+// NewBatch returns new empty batch.
+func NewBatch() *Batch {
+	return &Batch{}
+}
+
+// Add adds object entry to the batch (errors are added as error entries).
+func (b *Batch) Add(obj any, ext ...Extension) *Batch {
+	if err, ok := obj.(error); ok {
+		return b.AddError(err, ext...)
+	}
+	b.entries = append(b.entries, batchEntry{value: obj, ext: ext})
+	return b
+}
+
+// AddError adds error entry to the batch.
+func (b *Batch) AddError(err error, ext ...Extension) *Batch {
+	b.entries = append(b.entries, batchEntry{err: err, ext: ext})
+	return b
+}
+
+// Len returns number of entries.
+func (b *Batch) Len() int {
+	return len(b.entries)
+}
+
+// SuccessStatus sets status used when all entries succeeded.
+func (b *Batch) SuccessStatus(status int) *Batch {
+	b.success = status
+	return b
+}
+
+// batchResult is rendered entry of batch (headers keep all their values)
+type batchResult struct {
+	Status int             `json:"status"`
+	Header http.Header     `json:"headers,omitempty"`
+	Body   json.RawMessage `json:"body,omitempty"`
+}
+
+// renderResponse renders every entry and then the batch document itself
+func (b *Batch) renderResponse(ctx context.Context, j *jayson, rw *responseWriter, override ...Extension) error {
+	entryCtx := nestedContext(ctx)
+
+	results := make([]batchResult, 0, len(b.entries))
+	statuses := make([]int, 0, len(b.entries))
+	for _, entry := range b.entries {
+		var rendered Rendered
+		if entry.err != nil {
+			rendered = j.RenderError(entryCtx, entry.err, entry.ext...)
+		} else {
+			rendered = j.RenderResponse(entryCtx, entry.value, entry.ext...)
+		}
+
+		result := batchResult{
+			Status: rendered.Status,
+			Body:   rendered.Body,
+		}
+		for key, values := range rendered.Header {
+			if key == "Content-Type" {
+				continue
+			}
+			if result.Header == nil {
+				result.Header = make(http.Header)
+			}
+			result.Header[key] = values
+		}
+
+		results = append(results, result)
+		statuses = append(statuses, rendered.Status)
+	}
+
+	success := b.success
+	if success == 0 {
+		success = rw.statusCode
+	}
+	rw.WriteHeader(batchStatus(statuses, success))
+
+	doc := newObject(1)
+	doc.Set("results", results)
+
+	j.renderDocument(ctx, rw, b, doc, override...)
+
+	return nil
+}
+
+// batchStatus returns status of batch by its entries statuses
+func batchStatus(statuses []int, success int) int {
+	var failed []int
+	for _, status := range statuses {
+		if status >= http.StatusBadRequest {
+			failed = append(failed, status)
+		}
+	}
+
+	switch {
+	case len(failed) == 0:
+		return success
+	case len(failed) < len(statuses):
+		return http.StatusMultiStatus
+	}
+
+	// all failed, common status is used when there is one
+	for _, status := range failed[1:] {
+		if status != failed[0] {
+			return http.StatusMultiStatus
+		}
+	}
+	return failed[0]
+}
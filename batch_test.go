@@ -0,0 +1,127 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Peter Vrba
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package jayson
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type batchItem struct {
+	ID int `json:"id"`
+}
+
+func TestBatchStatus(t *testing.T) {
+	for _, item := range []struct {
+		statuses []int
+		expected int
+	}{
+		{nil, http.StatusOK},
+		{[]int{200, 201}, http.StatusOK},
+		{[]int{201, 404}, http.StatusMultiStatus},
+		{[]int{404, 404}, http.StatusNotFound},
+		{[]int{404, 409}, http.StatusMultiStatus},
+		{[]int{500}, http.StatusInternalServerError},
+	} {
+		assert.Equal(t, item.expected, batchStatus(item.statuses, http.StatusOK), item.statuses)
+	}
+}
+
+func TestBatch(t *testing.T) {
+	errNotFound := errors.New("item not found")
+
+	newJayson := func() Jayson {
+		j := New(DefaultSettings())
+		Must(
+			j.RegisterError(errNotFound, ExtStatus(http.StatusNotFound), ExtErrorCode("not_found")),
+			j.RegisterResponse(batchItem{}, ExtStatus(http.StatusCreated), ExtHeaderValue("X-Item", "created")),
+		)
+		return j
+	}
+
+	t.Run("test partial", func(t *testing.T) {
+		batch := NewBatch().
+			Add(batchItem{ID: 1}).
+			Add(errNotFound).
+			AddError(errNotFound, ExtStatus(http.StatusGone))
+		assert.Equal(t, 3, batch.Len())
+
+		rendered := newJayson().RenderResponse(context.Background(), batch)
+		assert.Equal(t, http.StatusMultiStatus, rendered.Status)
+		assert.Equal(t, "application/json", rendered.Header.Get("Content-Type"))
+		assert.JSONEq(t, `{"results":[
+			{"status":201,"headers":{"X-Item":["created"]},"body":{"id":1}},
+			{"status":404,"body":{"code":404,"error_code":"not_found","message":"item not found","status":"Not Found"}},
+			{"status":410,"body":{"code":410,"error_code":"not_found","message":"item not found","status":"Gone"}}
+		]}`, string(rendered.Body))
+	})
+
+	t.Run("test all succeeded", func(t *testing.T) {
+		rendered := newJayson().RenderResponse(context.Background(), NewBatch().Add(batchItem{ID: 1}).Add(map[string]any{"id": 2}))
+		assert.Equal(t, http.StatusOK, rendered.Status)
+
+		rendered = newJayson().RenderResponse(context.Background(), NewBatch().Add(batchItem{ID: 1}).SuccessStatus(http.StatusCreated))
+		assert.Equal(t, http.StatusCreated, rendered.Status)
+
+		rendered = newJayson().RenderResponse(context.Background(), NewBatch())
+		assert.Equal(t, http.StatusOK, rendered.Status)
+		assert.JSONEq(t, `{"results":[]}`, string(rendered.Body))
+	})
+
+	t.Run("test all failed", func(t *testing.T) {
+		rendered := newJayson().RenderResponse(context.Background(), NewBatch().Add(errNotFound).Add(errNotFound))
+		assert.Equal(t, http.StatusNotFound, rendered.Status)
+
+		rendered = newJayson().RenderResponse(context.Background(), NewBatch().Add(errNotFound).Add(errors.New("boom")))
+		assert.Equal(t, http.StatusMultiStatus, rendered.Status)
+	})
+
+	t.Run("test overrides", func(t *testing.T) {
+		rendered := newJayson().RenderResponse(context.Background(), NewBatch().Add(errNotFound), ExtStatus(http.StatusOK), ExtHeaderValue("X-Batch", "1"))
+		assert.Equal(t, http.StatusOK, rendered.Status)
+		assert.Equal(t, "1", rendered.Header.Get("X-Batch"))
+	})
+
+	t.Run("test header values", func(t *testing.T) {
+		rendered := newJayson().RenderResponse(context.Background(), NewBatch().Add(batchItem{ID: 1}, ExtHeaderValue("X-Item", "second")))
+		assert.JSONEq(t, `{"results":[{"status":201,"headers":{"X-Item":["created","second"]},"body":{"id":1}}]}`, string(rendered.Body))
+	})
+
+	t.Run("test request is not inspected by entries", func(t *testing.T) {
+		s := DefaultSettings()
+		s.FieldsQueryParameter = "fields"
+		s.Pretty = true
+		r := httptest.NewRequest(http.MethodGet, "/?fields=unknown", nil)
+		rw := httptest.NewRecorder()
+		New(s).Response(ContextWithRequest(r.Context(), r), rw, NewBatch().Add(batchItem{ID: 1}))
+		assert.Equal(t, http.StatusOK, rw.Code)
+		assert.Equal(t, "{\n  \"results\": [\n    {\n      \"status\": 200,\n      \"body\": {\n        \"id\": 1\n      }\n    }\n  ]\n}\n", rw.Body.String())
+	})
+}
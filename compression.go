@@ -0,0 +1,192 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Peter Vrba
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package jayson
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	// defaultCompressionMinSize is default minimal size of body (in bytes) to be compressed
+	defaultCompressionMinSize = 1024
+)
+
+// compressionEncodings are supported content encodings in order of preference
+var compressionEncodings = []string{"gzip", "deflate"}
+
+// compressionOptions holds compression configuration of response writer
+type compressionOptions struct {
+	enabled        bool
+	minSize        int
+	acceptEncoding string
+}
+
+// newCompressionOptions returns compression options for given settings and request (stored in context)
+func newCompressionOptions(ctx context.Context, s Settings) compressionOptions {
+	result := compressionOptions{
+		enabled: s.Compression,
+		minSize: s.CompressionMinSize,
+	}
+	if r, ok := ContextRequestValue(ctx); ok {
+		result.acceptEncoding = r.Header.Get("Accept-Encoding")
+	}
+	return result
+}
+
+// ExtCompression is an extension that enables/disables compression of response body.
+func ExtCompression(enabled bool) Extension {
+	return extResponseWriter(func(rw *responseWriter) {
+		rw.compression.enabled = enabled
+	})
+}
+
+// ExtCompressionMinSize is an extension that sets minimal size of response body (in bytes) to be compressed.
+func ExtCompressionMinSize(size int) Extension {
+	return extResponseWriter(func(rw *responseWriter) {
+		rw.compression.minSize = size
+	})
+}
+
+// compress compresses body with negotiated encoding and updates headers accordingly.
+// It returns false when body should be written as is.
+func (c compressionOptions) compress(header http.Header, statusCode int, body []byte) ([]byte, bool) {
+	encoding := c.negotiate(header, statusCode, len(body))
+	if encoding == "" {
+		return nil, false
+	}
+
+	var (
+		buffer bytes.Buffer
+		writer io.WriteCloser
+	)
+
+	switch encoding {
+	case "gzip":
+		writer = gzip.NewWriter(&buffer)
+	default:
+		writer = zlib.NewWriter(&buffer)
+	}
+
+	if _, err := writer.Write(body); err != nil {
+		return nil, false
+	}
+	if err := writer.Close(); err != nil {
+		return nil, false
+	}
+
+	header.Set("Content-Encoding", encoding)
+	header.Del("Content-Length")
+	setCompressedETag(header, encoding)
+
+	return buffer.Bytes(), true
+}
+
+// negotiate returns encoding negotiated for body of given size (empty when body is not compressed).
+// Vary header is added whenever compression could apply, so it is also used for 304 responses.
+func (c compressionOptions) negotiate(header http.Header, statusCode int, size int) string {
+	if !c.enabled || size == 0 || size < c.minSize {
+		return ""
+	}
+	if statusCode == http.StatusNoContent || statusCode == http.StatusNotModified || header.Get("Content-Encoding") != "" {
+		return ""
+	}
+
+	// response depends on Accept-Encoding even when it's not compressed
+	addVary(header, "Accept-Encoding")
+
+	return negotiateEncoding(c.acceptEncoding)
+}
+
+// setCompressedETag adds encoding suffix to strong ETag (representation differs, so strong ETag must differ too)
+func setCompressedETag(header http.Header, encoding string) {
+	if etag := header.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+		header.Set("ETag", strings.TrimSuffix(etag, `"`)+"-"+encoding+`"`)
+	}
+}
+
+// negotiateEncoding returns best supported encoding accepted by client (empty when none)
+func negotiateEncoding(accept string) string {
+	if accept == "" {
+		return ""
+	}
+
+	qualities := make(map[string]float64)
+	for _, part := range strings.Split(accept, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		quality := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+				quality = parsed
+			}
+		}
+		qualities[strings.ToLower(strings.TrimSpace(name))] = quality
+	}
+
+	var (
+		result string
+		best   float64
+	)
+
+	for _, encoding := range compressionEncodings {
+		quality, ok := qualities[encoding]
+		if !ok {
+			quality, ok = qualities["*"]
+		}
+		if ok && quality > best {
+			result, best = encoding, quality
+		}
+	}
+
+	return result
+}
+
+// addVary adds value to Vary header (if not already present)
+func addVary(header http.Header, value string) {
+	for _, existing := range header.Values("Vary") {
+		for _, item := range strings.Split(existing, ",") {
+			if strings.EqualFold(strings.TrimSpace(item), value) || strings.TrimSpace(item) == "*" {
+				return
+			}
+		}
+	}
+	header.Add("Vary", value)
+}
+
+// trimCompressedETag returns ETag without suffix added by compression
+func trimCompressedETag(etag string) string {
+	for _, encoding := range compressionEncodings {
+		if trimmed, ok := strings.CutSuffix(etag, "-"+encoding+`"`); ok {
+			return trimmed + `"`
+		}
+	}
+	return etag
+}
@@ -0,0 +1,190 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Peter Vrba
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package jayson
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNegotiateEncoding(t *testing.T) {
+	for _, item := range []struct {
+		accept   string
+		expected string
+	}{
+		{"", ""},
+		{"identity", ""},
+		{"gzip", "gzip"},
+		{"deflate", "deflate"},
+		{"gzip, deflate, br", "gzip"},
+		{"deflate, gzip", "gzip"},
+		{"gzip;q=0.5, deflate", "deflate"},
+		{"gzip;q=0, deflate;q=0", ""},
+		{"*", "gzip"},
+		{"*;q=0.1, deflate;q=0.5", "deflate"},
+		{"GZIP", "gzip"},
+	} {
+		assert.Equal(t, item.expected, negotiateEncoding(item.accept), item.accept)
+	}
+}
+
+func TestCompression(t *testing.T) {
+	large := map[string]any{"value": strings.Repeat("jayson ", 500)}
+
+	respond := func(s Settings, accept string, what any, ext ...Extension) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		if accept != "" {
+			r.Header.Set("Accept-Encoding", accept)
+		}
+		rw := httptest.NewRecorder()
+		New(s).Response(ContextWithRequest(r.Context(), r), rw, what, ext...)
+		return rw
+	}
+
+	enabled := func() Settings {
+		s := DefaultSettings()
+		s.Compression = true
+		return s
+	}
+
+	expected := string(New(DefaultSettings()).RenderResponse(context.Background(), large).Body)
+
+	t.Run("test disabled by default", func(t *testing.T) {
+		rw := respond(DefaultSettings(), "gzip", large)
+		assert.Empty(t, rw.Header().Get("Content-Encoding"))
+		assert.Empty(t, rw.Header().Get("Vary"))
+		assert.Equal(t, expected, rw.Body.String())
+	})
+
+	t.Run("test gzip", func(t *testing.T) {
+		rw := respond(enabled(), "gzip, deflate", large)
+		assert.Equal(t, "gzip", rw.Header().Get("Content-Encoding"))
+		assert.Equal(t, "Accept-Encoding", rw.Header().Get("Vary"))
+		assert.Less(t, rw.Body.Len(), len(expected))
+
+		reader, err := gzip.NewReader(rw.Body)
+		require.NoError(t, err)
+		body, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		assert.Equal(t, expected, string(body))
+	})
+
+	t.Run("test deflate", func(t *testing.T) {
+		rw := respond(enabled(), "deflate", large)
+		assert.Equal(t, "deflate", rw.Header().Get("Content-Encoding"))
+
+		reader, err := zlib.NewReader(rw.Body)
+		require.NoError(t, err)
+		body, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		assert.Equal(t, expected, string(body))
+	})
+
+	t.Run("test not accepted", func(t *testing.T) {
+		rw := respond(enabled(), "", large)
+		assert.Empty(t, rw.Header().Get("Content-Encoding"))
+		assert.Equal(t, "Accept-Encoding", rw.Header().Get("Vary"))
+		assert.Equal(t, expected, rw.Body.String())
+	})
+
+	t.Run("test min size", func(t *testing.T) {
+		rw := respond(enabled(), "gzip", map[string]any{"id": 1})
+		assert.Empty(t, rw.Header().Get("Content-Encoding"))
+		assert.Empty(t, rw.Header().Get("Vary"))
+
+		rw = respond(enabled(), "gzip", map[string]any{"id": 1}, ExtCompressionMinSize(1))
+		assert.Equal(t, "gzip", rw.Header().Get("Content-Encoding"))
+	})
+
+	t.Run("test extension", func(t *testing.T) {
+		rw := respond(DefaultSettings(), "gzip", large, ExtCompression(true))
+		assert.Equal(t, "gzip", rw.Header().Get("Content-Encoding"))
+
+		rw = respond(enabled(), "gzip", large, ExtCompression(false))
+		assert.Empty(t, rw.Header().Get("Content-Encoding"))
+	})
+
+	t.Run("test existing Vary", func(t *testing.T) {
+		rw := respond(enabled(), "gzip", large, ExtHeaderValue("Vary", "Origin"))
+		assert.Equal(t, []string{"Origin", "Accept-Encoding"}, rw.Header().Values("Vary"))
+
+		rw = respond(enabled(), "gzip", large, ExtHeaderValue("Vary", "accept-encoding"))
+		assert.Equal(t, []string{"accept-encoding"}, rw.Header().Values("Vary"))
+	})
+
+	t.Run("test error", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("Accept-Encoding", "gzip")
+		rw := httptest.NewRecorder()
+		New(enabled()).Error(ContextWithRequest(r.Context(), r), rw, ErrPreconditionFailed, ExtCompressionMinSize(1))
+		assert.Equal(t, http.StatusPreconditionFailed, rw.Code)
+		assert.Equal(t, "gzip", rw.Header().Get("Content-Encoding"))
+	})
+
+	t.Run("test ETag", func(t *testing.T) {
+		rw := respond(enabled(), "gzip", large, ExtETag(true))
+		etag := rw.Header().Get("ETag")
+		assert.Regexp(t, `^"[0-9a-f]{32}-gzip"$`, etag)
+
+		// compressed tag is recognized in conditional request
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("Accept-Encoding", "gzip")
+		r.Header.Set("If-None-Match", etag)
+		rw = httptest.NewRecorder()
+		New(enabled()).Response(ContextWithRequest(r.Context(), r), rw, large, ExtETag(true))
+		assert.Equal(t, http.StatusNotModified, rw.Code)
+		assert.Empty(t, rw.Header().Get("Content-Encoding"))
+		assert.Empty(t, rw.Body.String())
+
+		// 304 has the same ETag and Vary as 200 would have
+		assert.Equal(t, etag, rw.Header().Get("ETag"))
+		assert.Equal(t, "Accept-Encoding", rw.Header().Get("Vary"))
+
+		// client without gzip gets plain ETag (and Vary)
+		r.Header.Del("Accept-Encoding")
+		rw = httptest.NewRecorder()
+		New(enabled()).Response(ContextWithRequest(r.Context(), r), rw, large, ExtETag(true))
+		assert.Equal(t, http.StatusNotModified, rw.Code)
+		assert.Equal(t, strings.TrimSuffix(etag, `-gzip"`)+`"`, rw.Header().Get("ETag"))
+		assert.Equal(t, "Accept-Encoding", rw.Header().Get("Vary"))
+	})
+
+	t.Run("test rendered is not compressed", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("Accept-Encoding", "gzip")
+		rendered := New(enabled()).RenderResponse(ContextWithRequest(r.Context(), r), large)
+		assert.Equal(t, expected, string(rendered.Body))
+		assert.Empty(t, rendered.Header.Get("Content-Encoding"))
+	})
+}
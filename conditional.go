@@ -0,0 +1,157 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Peter Vrba
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package jayson
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ETagger is interface for response objects that provide their own ETag.
+type ETagger interface {
+	// ETag returns entity tag of the object (quotes are added when missing, empty is ignored).
+	ETag() string
+}
+
+// LastModifier is interface for response objects that provide time of their last modification.
+type LastModifier interface {
+	// LastModified returns time of last modification (zero is ignored).
+	LastModified() time.Time
+}
+
+// ExtETag is an extension that enables/disables computing of strong ETag from encoded body.
+func ExtETag(enabled bool) Extension {
+	return extResponseWriter(func(rw *responseWriter) {
+		rw.etag = enabled
+	})
+}
+
+// CheckIfMatch checks If-Match precondition of request against current ETag of the resource.
+// It returns ErrPreconditionFailed (registered with 412 status) when precondition fails.
+// Current ETag can be obtained from object or from rendered response (RenderResponse(...).Header.Get("ETag")).
+func CheckIfMatch(r *http.Request, etag string) error {
+	header := r.Header.Get("If-Match")
+	if header == "" {
+		return nil
+	}
+
+	etag = quoteETag(etag)
+
+	// If-Match uses strong comparison, so weak tags never match
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" && etag != "" {
+			return nil
+		}
+		if etag != "" && !strings.HasPrefix(etag, "W/") && trimCompressedETag(candidate) == etag {
+			return nil
+		}
+	}
+
+	return ErrPreconditionFailed
+}
+
+// applyConditional sets ETag and Last-Modified headers of successful response and evaluates conditional GET
+// (If-None-Match, If-Modified-Since), the response is turned into 304 without body when it's not modified
+func applyConditional(ctx context.Context, rw *responseWriter, what any) {
+	if rw.statusCode < http.StatusOK || rw.statusCode >= http.StatusMultipleChoices {
+		return
+	}
+
+	var (
+		etag         string
+		lastModified time.Time
+	)
+
+	if tagger, ok := what.(ETagger); ok {
+		etag = quoteETag(tagger.ETag())
+	}
+	if etag == "" && rw.etag {
+		sum := sha256.Sum256(rw.buffer.Bytes())
+		etag = `"` + hex.EncodeToString(sum[:16]) + `"`
+	}
+	if modifier, ok := what.(LastModifier); ok {
+		lastModified = modifier.LastModified()
+	}
+
+	if etag != "" {
+		rw.Header().Set("ETag", etag)
+	}
+	if !lastModified.IsZero() {
+		rw.Header().Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	r, ok := ContextRequestValue(ctx)
+	if !ok || (r.Method != http.MethodGet && r.Method != http.MethodHead) {
+		return
+	}
+
+	if isNotModified(r, etag, lastModified) {
+		// 304 carries Vary and ETag that 200 response would have (body is not compressed anymore)
+		if encoding := rw.compression.negotiate(rw.Header(), rw.statusCode, rw.buffer.Len()); encoding != "" {
+			setCompressedETag(rw.Header(), encoding)
+		}
+		rw.statusCode = http.StatusNotModified
+		rw.buffer.Reset()
+		rw.Header().Del("Content-Type")
+		rw.Header().Del("Content-Length")
+	}
+}
+
+// isNotModified evaluates If-None-Match and If-Modified-Since (only when If-None-Match is not present)
+func isNotModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if header := r.Header.Get("If-None-Match"); header != "" {
+		if etag == "" {
+			return false
+		}
+		// If-None-Match uses weak comparison (tags of compressed representations match too)
+		for _, candidate := range strings.Split(header, ",") {
+			candidate = trimCompressedETag(strings.TrimSpace(candidate))
+			if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+				return true
+			}
+		}
+		return false
+	}
+
+	if header := r.Header.Get("If-Modified-Since"); header != "" && !lastModified.IsZero() {
+		since, err := http.ParseTime(header)
+		return err == nil && !lastModified.Truncate(time.Second).After(since)
+	}
+
+	return false
+}
+
+// quoteETag adds quotes to ETag if missing
+func quoteETag(etag string) string {
+	if etag == "" || strings.HasSuffix(etag, `"`) {
+		return etag
+	}
+	return `"` + etag + `"`
+}
@@ -0,0 +1,174 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Peter Vrba
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package jayson
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type conditionalResponse struct {
+	ID       int       `json:"id"`
+	Modified time.Time `json:"-"`
+}
+
+func (c conditionalResponse) ETag() string {
+	return "v1"
+}
+
+func (c conditionalResponse) LastModified() time.Time {
+	return c.Modified
+}
+
+func TestETag(t *testing.T) {
+	requestContext := func(method string, header http.Header) context.Context {
+		r := httptest.NewRequest(method, "/", nil)
+		r.Header = header
+		return ContextWithRequest(context.Background(), r)
+	}
+
+	t.Run("test disabled by default", func(t *testing.T) {
+		rendered := New(DefaultSettings()).RenderResponse(context.Background(), map[string]any{"id": 1})
+		assert.Empty(t, rendered.Header.Get("ETag"))
+	})
+
+	t.Run("test computed from body", func(t *testing.T) {
+		j := New(DefaultSettings())
+		first := j.RenderResponse(context.Background(), map[string]any{"id": 1}, ExtETag(true))
+		second := j.RenderResponse(context.Background(), map[string]any{"id": 1}, ExtETag(true))
+		other := j.RenderResponse(context.Background(), map[string]any{"id": 2}, ExtETag(true))
+
+		assert.Regexp(t, `^"[0-9a-f]{32}"$`, first.Header.Get("ETag"))
+		assert.Equal(t, first.Header.Get("ETag"), second.Header.Get("ETag"))
+		assert.NotEqual(t, first.Header.Get("ETag"), other.Header.Get("ETag"))
+	})
+
+	t.Run("test settings", func(t *testing.T) {
+		s := DefaultSettings()
+		s.ETag = true
+		rendered := New(s).RenderResponse(context.Background(), map[string]any{"id": 1})
+		assert.NotEmpty(t, rendered.Header.Get("ETag"))
+	})
+
+	t.Run("test not set for errors", func(t *testing.T) {
+		rendered := New(DefaultSettings()).RenderResponse(context.Background(), map[string]any{"id": 1}, ExtETag(true), ExtStatus(http.StatusBadRequest))
+		assert.Empty(t, rendered.Header.Get("ETag"))
+	})
+
+	t.Run("test object provided", func(t *testing.T) {
+		modified := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+		rendered := New(DefaultSettings()).RenderResponse(context.Background(), conditionalResponse{ID: 1, Modified: modified})
+		assert.Equal(t, `"v1"`, rendered.Header.Get("ETag"))
+		assert.Equal(t, modified.Format(http.TimeFormat), rendered.Header.Get("Last-Modified"))
+	})
+
+	t.Run("test If-None-Match", func(t *testing.T) {
+		for _, item := range []struct {
+			method string
+			header string
+			status int
+		}{
+			{http.MethodGet, `"v1"`, http.StatusNotModified},
+			{http.MethodGet, `W/"v1"`, http.StatusNotModified},
+			{http.MethodGet, `"v0", "v1"`, http.StatusNotModified},
+			{http.MethodGet, `*`, http.StatusNotModified},
+			{http.MethodHead, `"v1"`, http.StatusNotModified},
+			{http.MethodGet, `"v2"`, http.StatusOK},
+			{http.MethodPost, `"v1"`, http.StatusOK},
+		} {
+			ctx := requestContext(item.method, http.Header{"If-None-Match": {item.header}})
+			rendered := New(DefaultSettings()).RenderResponse(ctx, conditionalResponse{ID: 1})
+			assert.Equal(t, item.status, rendered.Status, item.header)
+			if item.status == http.StatusNotModified {
+				assert.Empty(t, rendered.Body)
+				assert.Empty(t, rendered.Header.Get("Content-Type"))
+				assert.Equal(t, `"v1"`, rendered.Header.Get("ETag"))
+			} else {
+				assert.NotEmpty(t, rendered.Body)
+			}
+		}
+	})
+
+	t.Run("test If-Modified-Since", func(t *testing.T) {
+		modified := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+		for _, item := range []struct {
+			since  time.Time
+			status int
+		}{
+			{modified, http.StatusNotModified},
+			{modified.Add(time.Hour), http.StatusNotModified},
+			{modified.Add(-time.Hour), http.StatusOK},
+		} {
+			ctx := requestContext(http.MethodGet, http.Header{"If-Modified-Since": {item.since.Format(http.TimeFormat)}})
+			rendered := New(DefaultSettings()).RenderResponse(ctx, conditionalResponse{ID: 1, Modified: modified})
+			assert.Equal(t, item.status, rendered.Status)
+		}
+	})
+
+	t.Run("test Response", func(t *testing.T) {
+		j := New(DefaultSettings())
+		first := httptest.NewRecorder()
+		j.Response(context.Background(), first, map[string]any{"id": 1}, ExtETag(true))
+
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("If-None-Match", first.Header().Get("ETag"))
+		rw := httptest.NewRecorder()
+		j.Response(ContextWithRequest(r.Context(), r), rw, map[string]any{"id": 1}, ExtETag(true))
+		assert.Equal(t, http.StatusNotModified, rw.Code)
+		assert.Empty(t, rw.Body.String())
+	})
+}
+
+func TestCheckIfMatch(t *testing.T) {
+	request := func(header string) *http.Request {
+		r := httptest.NewRequest(http.MethodPut, "/", nil)
+		if header != "" {
+			r.Header.Set("If-Match", header)
+		}
+		return r
+	}
+
+	assert.NoError(t, CheckIfMatch(request(""), "v1"))
+	assert.NoError(t, CheckIfMatch(request(`"v1"`), "v1"))
+	assert.NoError(t, CheckIfMatch(request(`"v0", "v1"`), `"v1"`))
+	assert.NoError(t, CheckIfMatch(request(`*`), "v1"))
+	assert.NoError(t, CheckIfMatch(request(`"v1-gzip"`), "v1"))
+	assert.ErrorIs(t, CheckIfMatch(request(`"v2"`), "v1"), ErrPreconditionFailed)
+	assert.ErrorIs(t, CheckIfMatch(request(`W/"v1"`), "v1"), ErrPreconditionFailed)
+	assert.ErrorIs(t, CheckIfMatch(request(`*`), ""), ErrPreconditionFailed)
+
+	t.Run("test registered status", func(t *testing.T) {
+		err := CheckIfMatch(request(`"v2"`), "v1")
+		require.Error(t, err)
+		rendered := New(DefaultSettings()).RenderError(context.Background(), err)
+		assert.Equal(t, http.StatusPreconditionFailed, rendered.Status)
+	})
+}
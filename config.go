@@ -0,0 +1,259 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Peter Vrba
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package jayson
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"io"
+	"io/fs"
+	"net/http"
+	"reflect"
+	"sort"
+)
+
+// Config is declarative configuration of errors and settings.
+// It is parsed from JSON or YAML document, for example:
+//
+//	settings:
+//	  production: true
+//	errors:
+//	  user_not_found:
+//	    status: 404
+//	    detail: "User does not exist"
+//	    headers:
+//	      X-Error: user
+//	    omit: [status]
+//
+// Errors are identified by their error code (see ErrorCoder) among registered errors,
+// or by names provided to Validate/Apply (for sentinel errors).
+type Config struct {
+	Settings *ConfigSettings        `json:"settings" yaml:"settings"`
+	Errors   map[string]ConfigError `json:"errors" yaml:"errors"`
+}
+
+// ConfigError is declarative configuration of single error.
+type ConfigError struct {
+	Status  int               `json:"status" yaml:"status"`
+	Headers map[string]string `json:"headers" yaml:"headers"`
+	Detail  string            `json:"detail" yaml:"detail"`
+	Omit    []string          `json:"omit" yaml:"omit"`
+}
+
+// ConfigSettings is declarative configuration of settings, only provided values are applied.
+type ConfigSettings struct {
+	DefaultErrorStatus        *int    `json:"default_error_status" yaml:"default_error_status"`
+	DefaultErrorMessageKey    *string `json:"default_error_message_key" yaml:"default_error_message_key"`
+	DefaultErrorStatusCodeKey *string `json:"default_error_status_code_key" yaml:"default_error_status_code_key"`
+	DefaultErrorStatusTextKey *string `json:"default_error_status_text_key" yaml:"default_error_status_text_key"`
+	DefaultResponseStatus     *int    `json:"default_response_status" yaml:"default_response_status"`
+	DefaultUnwrapObjectKey    *string `json:"default_unwrap_object_key" yaml:"default_unwrap_object_key"`
+	DefaultErrorIDKey         *string `json:"default_error_id_key" yaml:"default_error_id_key"`
+	DefaultErrorDebugKey      *string `json:"default_error_debug_key" yaml:"default_error_debug_key"`
+	DefaultErrorCodeKey       *string `json:"default_error_code_key" yaml:"default_error_code_key"`
+	DefaultErrorDetailKey     *string `json:"default_error_detail_key" yaml:"default_error_detail_key"`
+	Production                *bool   `json:"production" yaml:"production"`
+	ProductionErrorMessage    *string `json:"production_error_message" yaml:"production_error_message"`
+	DebugErrors               *bool   `json:"debug_errors" yaml:"debug_errors"`
+}
+
+// ParseConfig parses config from JSON or YAML document, unknown fields are reported as errors.
+func ParseConfig(data []byte) (*Config, error) {
+	result := &Config{}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	// empty document is valid config
+	if err := decoder.Decode(result); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+	}
+
+	return result, nil
+}
+
+// LoadConfigFS loads config from given file system (so configs can be embedded).
+func LoadConfigFS(fsys fs.FS, name string) (*Config, error) {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+	return ParseConfig(data)
+}
+
+// ApplySettings applies configured settings to given settings.
+// It can be passed to Child or ContextWithSettings.
+func (c *Config) ApplySettings(s *Settings) {
+	if c.Settings == nil {
+		return
+	}
+	setIfNotNil(&s.DefaultErrorStatus, c.Settings.DefaultErrorStatus)
+	setIfNotNil(&s.DefaultErrorMessageKey, c.Settings.DefaultErrorMessageKey)
+	setIfNotNil(&s.DefaultErrorStatusCodeKey, c.Settings.DefaultErrorStatusCodeKey)
+	setIfNotNil(&s.DefaultErrorStatusTextKey, c.Settings.DefaultErrorStatusTextKey)
+	setIfNotNil(&s.DefaultResponseStatus, c.Settings.DefaultResponseStatus)
+	setIfNotNil(&s.DefaultUnwrapObjectKey, c.Settings.DefaultUnwrapObjectKey)
+	setIfNotNil(&s.DefaultErrorIDKey, c.Settings.DefaultErrorIDKey)
+	setIfNotNil(&s.DefaultErrorDebugKey, c.Settings.DefaultErrorDebugKey)
+	setIfNotNil(&s.DefaultErrorCodeKey, c.Settings.DefaultErrorCodeKey)
+	setIfNotNil(&s.DefaultErrorDetailKey, c.Settings.DefaultErrorDetailKey)
+	setIfNotNil(&s.Production, c.Settings.Production)
+	setIfNotNil(&s.ProductionErrorMessage, c.Settings.ProductionErrorMessage)
+	setIfNotNil(&s.DebugErrors, c.Settings.DebugErrors)
+}
+
+// Validate validates config against given instance, named errors are looked up before registered error codes.
+func (c *Config) Validate(j Jayson, named map[string]error) error {
+	_, err := c.resolve(j, named)
+	return err
+}
+
+// Apply validates config and registers configured extensions of errors.
+// Configured extensions are added after extensions registered in code, and they replace extensions
+// of previously applied config (so changed config can also remove headers or statuses).
+func (c *Config) Apply(j Jayson, named map[string]error) error {
+	resolved, err := c.resolve(j, named)
+	if err != nil {
+		return err
+	}
+
+	// other implementations of Jayson only support registration
+	internal, ok := j.(*jayson)
+	if !ok {
+		for _, name := range sortedKeys(c.Errors) {
+			if err := j.RegisterError(resolved[name], c.Errors[name].extensions()...); err != nil && !errors.Is(err, Warning) {
+				return err
+			}
+		}
+		return nil
+	}
+
+	items := make(map[error][]Extension, len(c.Errors))
+	for _, name := range sortedKeys(c.Errors) {
+		target := resolved[name]
+		items[target] = append(items[target], c.Errors[name].extensions()...)
+	}
+	internal.registryConfigErrors.Replace(items)
+
+	return nil
+}
+
+// resolve resolves all configured errors
+func (c *Config) resolve(j Jayson, named map[string]error) (map[string]error, error) {
+	var (
+		result = make(map[string]error, len(c.Errors))
+		errs   []error
+	)
+
+	if c.Settings != nil {
+		for _, status := range []*int{c.Settings.DefaultErrorStatus, c.Settings.DefaultResponseStatus} {
+			if status != nil && !isValidStatus(*status) {
+				errs = append(errs, fmt.Errorf("%w: invalid settings status %d", ErrInvalidConfig, *status))
+			}
+		}
+	}
+
+	for _, name := range sortedKeys(c.Errors) {
+		if status := c.Errors[name].Status; status != 0 && !isValidStatus(status) {
+			errs = append(errs, fmt.Errorf("%w: invalid status %d of error `%s`", ErrInvalidConfig, status, name))
+		}
+
+		if target, ok := named[name]; ok && target != nil {
+			// configured extensions are stored by error, so it must be usable as map key
+			if !reflect.TypeOf(target).Comparable() {
+				errs = append(errs, fmt.Errorf("%w: error %T of `%s` is not comparable", ErrInvalidConfig, target, name))
+				continue
+			}
+			result[name] = target
+			continue
+		}
+
+		// look up registered errors by their code
+		if internal, ok := j.(*jayson); ok {
+			target, found := internal.registryErrors.Find(func(err error) bool {
+				coder, ok := err.(ErrorCoder)
+				return ok && coder.ErrorCode() == name
+			})
+			if found {
+				result[name] = target
+				continue
+			}
+		}
+
+		errs = append(errs, fmt.Errorf("%w: unknown error `%s`", ErrInvalidConfig, name))
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return result, nil
+}
+
+// extensions returns extensions for configured error (omitted keys go last)
+func (c ConfigError) extensions() []Extension {
+	var result []Extension
+
+	if c.Status != 0 {
+		result = append(result, ExtStatus(c.Status))
+	}
+	for _, key := range sortedKeys(c.Headers) {
+		result = append(result, ExtHeaderValue(key, c.Headers[key]))
+	}
+	if c.Detail != "" {
+		result = append(result, ExtErrorDetail(c.Detail))
+	}
+	if len(c.Omit) > 0 {
+		result = append(result, ExtOmitSettingsKey(func(Settings) []string {
+			return c.Omit
+		}))
+	}
+
+	return result
+}
+
+// isValidStatus checks if status is valid HTTP status
+func isValidStatus(status int) bool {
+	return status >= http.StatusContinue && status <= 599
+}
+
+// setIfNotNil sets target to value if value is not nil
+func setIfNotNil[T any](target *T, value *T) {
+	if value != nil {
+		*target = *value
+	}
+}
+
+// sortedKeys returns sorted keys of given map (for deterministic order)
+func sortedKeys[V any](m map[string]V) []string {
+	result := make([]string, 0, len(m))
+	for k := range m {
+		result = append(result, k)
+	}
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,203 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Peter Vrba
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package jayson
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestParseConfig(t *testing.T) {
+	t.Run("test yaml", func(t *testing.T) {
+		cfg, err := ParseConfig([]byte(`
+settings:
+  production: true
+  default_error_message_key: msg
+errors:
+  not_found:
+    status: 404
+    detail: Not here
+    headers:
+      X-Error: missing
+    omit: [status]
+`))
+		require.NoError(t, err)
+		require.NotNil(t, cfg.Settings)
+		assert.True(t, *cfg.Settings.Production)
+		assert.Equal(t, ConfigError{
+			Status:  http.StatusNotFound,
+			Detail:  "Not here",
+			Headers: map[string]string{"X-Error": "missing"},
+			Omit:    []string{"status"},
+		}, cfg.Errors["not_found"])
+	})
+
+	t.Run("test json", func(t *testing.T) {
+		cfg, err := ParseConfig([]byte(`{"errors": {"not_found": {"status": 404}}}`))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, cfg.Errors["not_found"].Status)
+	})
+
+	t.Run("test empty", func(t *testing.T) {
+		cfg, err := ParseConfig(nil)
+		require.NoError(t, err)
+		assert.Empty(t, cfg.Errors)
+	})
+
+	t.Run("test unknown field", func(t *testing.T) {
+		_, err := ParseConfig([]byte(`{"errors": {"not_found": {"statuz": 404}}}`))
+		assert.ErrorIs(t, err, ErrInvalidConfig)
+	})
+}
+
+func TestLoadConfigFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"config.yaml": &fstest.MapFile{Data: []byte("errors:\n  not_found:\n    status: 404\n")},
+	}
+
+	cfg, err := LoadConfigFS(fsys, "config.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, cfg.Errors["not_found"].Status)
+
+	_, err = LoadConfigFS(fsys, "missing.yaml")
+	assert.Error(t, err)
+}
+
+func TestConfig_ApplySettings(t *testing.T) {
+	cfg, err := ParseConfig([]byte("settings:\n  production: true\n  default_error_status: 503\n"))
+	require.NoError(t, err)
+
+	s := DefaultSettings()
+	cfg.ApplySettings(&s)
+	assert.True(t, s.Production)
+	assert.Equal(t, http.StatusServiceUnavailable, s.DefaultErrorStatus)
+	assert.Equal(t, "message", s.DefaultErrorMessageKey)
+}
+
+// configMultiError is uncomparable error
+type configMultiError []string
+
+func (c configMultiError) Error() string { return strings.Join(c, ", ") }
+
+func TestConfig_Apply(t *testing.T) {
+	errSentinel := errors.New("sentinel")
+	errStructured := NewError("user_not_found").Status(http.StatusNotFound)
+
+	t.Run("test unknown errors fail validation", func(t *testing.T) {
+		cfg, err := ParseConfig([]byte("errors:\n  unknown: {status: 404}\n  bad_status: {status: 1000}\n"))
+		require.NoError(t, err)
+
+		jay := New(DefaultSettings())
+		err = cfg.Validate(jay, map[string]error{"bad_status": errSentinel})
+		assert.ErrorIs(t, err, ErrInvalidConfig)
+		assert.ErrorContains(t, err, "unknown error `unknown`")
+		assert.ErrorContains(t, err, "invalid status 1000")
+		assert.ErrorIs(t, cfg.Apply(jay, nil), ErrInvalidConfig)
+	})
+
+	t.Run("test uncomparable error fails validation", func(t *testing.T) {
+		cfg, err := ParseConfig([]byte("errors:\n  multi: {status: 400}\n"))
+		require.NoError(t, err)
+
+		jay := New(DefaultSettings())
+		err = cfg.Apply(jay, map[string]error{"multi": configMultiError{"a", "b"}})
+		assert.ErrorIs(t, err, ErrInvalidConfig)
+		assert.ErrorContains(t, err, "is not comparable")
+	})
+
+	t.Run("test apply named and coded errors", func(t *testing.T) {
+		cfg, err := ParseConfig([]byte(`
+errors:
+  sentinel:
+    status: 409
+    omit: [status]
+  user_not_found:
+    detail: User does not exist
+    headers:
+      X-Error: user
+`))
+		require.NoError(t, err)
+
+		jay := New(DefaultSettings())
+		require.NoError(t, jay.RegisterError(errStructured, ExtHeaderValue("X-Registered", "yes")))
+		require.NoError(t, cfg.Apply(jay, map[string]error{"sentinel": errSentinel}))
+
+		rendered := jay.RenderError(context.Background(), errSentinel)
+		assert.Equal(t, http.StatusConflict, rendered.Status)
+		assert.JSONEq(t, `{"code":409,"message":"sentinel"}`, string(rendered.Body))
+
+		// existing registration is kept
+		rendered = jay.RenderError(context.Background(), errStructured.Field("id", 1))
+		assert.Equal(t, http.StatusNotFound, rendered.Status)
+		assert.Equal(t, "user", rendered.Header.Get("X-Error"))
+		assert.Equal(t, "yes", rendered.Header.Get("X-Registered"))
+		assert.JSONEq(t, `{"code":404,"status":"Not Found","message":"user_not_found","error_code":"user_not_found","detail":"User does not exist","id":1}`, string(rendered.Body))
+	})
+
+	t.Run("test apply replaces previous config", func(t *testing.T) {
+		jay := New(DefaultSettings())
+		require.NoError(t, jay.RegisterError(errSentinel, ExtStatus(http.StatusBadRequest), ExtHeaderValue("X-Registered", "yes")))
+
+		first, err := ParseConfig([]byte("errors:\n  sentinel: {status: 409, headers: {X-A: b}}\n"))
+		require.NoError(t, err)
+		require.NoError(t, first.Apply(jay, map[string]error{"sentinel": errSentinel}))
+		require.NoError(t, first.Apply(jay, map[string]error{"sentinel": errSentinel}))
+
+		rendered := jay.RenderError(context.Background(), errSentinel)
+		assert.Equal(t, http.StatusConflict, rendered.Status)
+		assert.Equal(t, []string{"b"}, rendered.Header.Values("X-A"))
+
+		// changed config removes previous header and status
+		second, err := ParseConfig([]byte("errors: {}\n"))
+		require.NoError(t, err)
+		require.NoError(t, second.Apply(jay, nil))
+
+		rendered = jay.RenderError(context.Background(), errSentinel)
+		assert.Equal(t, http.StatusBadRequest, rendered.Status)
+		assert.Empty(t, rendered.Header.Values("X-A"))
+		assert.Equal(t, "yes", rendered.Header.Get("X-Registered"))
+	})
+
+	t.Run("test omit nested key", func(t *testing.T) {
+		cfg, err := ParseConfig([]byte("errors:\n  sentinel: {omit: [error.status]}\n"))
+		require.NoError(t, err)
+
+		jay := New(Settings{
+			DefaultErrorMessageKey:    "error.message",
+			DefaultErrorStatusTextKey: "error.status",
+			DefaultErrorStatusCodeKey: KeyDisabled,
+		})
+		require.NoError(t, cfg.Apply(jay, map[string]error{"sentinel": errSentinel}))
+
+		rendered := jay.RenderError(context.Background(), errSentinel)
+		assert.JSONEq(t, `{"error":{"message":"sentinel"}}`, string(rendered.Body))
+	})
+}
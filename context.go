@@ -24,7 +24,10 @@
 
 package jayson
 
-import "context"
+import (
+	"context"
+	"net/http"
+)
 
 // contextKey is a type for storing values in context.
 type contextKey int
@@ -38,14 +41,107 @@ const (
 
 	// contextObjectKey is the key used to store the object value in the context.
 	contextObjectKey
+
+	// contextErrorIDKey is the key used to store the generated error id in the context.
+	contextErrorIDKey
+
+	// contextErrorDebugKey is the key used to enable error debug information in the context.
+	contextErrorDebugKey
+
+	// contextSettingsOverridesKey is the key used to store settings overrides in the context.
+	contextSettingsOverridesKey
+
+	// contextRequestKey is the key used to store the http request in the context.
+	contextRequestKey
+
+	// contextViewKey is the key used to store the views of current user in the context.
+	contextViewKey
+
+	// contextRateLimitKey is the key used to store the rate limit result in the context.
+	contextRateLimitKey
+
+	// contextVersionKey is the key used to store the requested API version in the context.
+	contextVersionKey
 )
 
+// ContextWithRequest returns context with http request, so jayson can inspect request (query, headers)
+// while rendering response. Usually RequestMiddleware is used.
+func ContextWithRequest(ctx context.Context, r *http.Request) context.Context {
+	return context.WithValue(ctx, contextRequestKey, r)
+}
+
+// ContextRequestValue returns the http request stored in the context.
+func ContextRequestValue(ctx context.Context) (*http.Request, bool) {
+	r, ok := ctx.Value(contextRequestKey).(*http.Request)
+	return r, ok && r != nil
+}
+
+// ContextWithView returns context with views (e.g. roles of current user), fields tagged
+// with `jayson:"view=admin"` are rendered only when one of their views is present in context.
+func ContextWithView(ctx context.Context, views ...string) context.Context {
+	return context.WithValue(ctx, contextViewKey, views)
+}
+
+// ContextViewValue returns the views stored in the context.
+func ContextViewValue(ctx context.Context) []string {
+	views, _ := ctx.Value(contextViewKey).([]string)
+	return views
+}
+
+// ContextWithRateLimit returns context with rate limit result of current request, it is used by ExtRateLimit.
+func ContextWithRateLimit(ctx context.Context, rl RateLimit) context.Context {
+	return context.WithValue(ctx, contextRateLimitKey, rl)
+}
+
+// ContextRateLimitValue returns the rate limit result stored in the context.
+func ContextRateLimitValue(ctx context.Context) (RateLimit, bool) {
+	rl, ok := ctx.Value(contextRateLimitKey).(RateLimit)
+	return rl, ok
+}
+
+// ContextWithSettings returns context with settings override, that is applied to instance settings
+// by Error, Response (and their Render variants), so all extensions see overridden settings.
+// Overrides are cumulative, they are applied in order they were added.
+// Production mode is kept at instance value (debug information cannot be enabled in production).
+func ContextWithSettings(ctx context.Context, override func(*Settings)) context.Context {
+	if override == nil {
+		return ctx
+	}
+	existing := contextSettingsOverrides(ctx)
+	overrides := make([]func(*Settings), 0, len(existing)+1)
+	overrides = append(overrides, existing...)
+	return context.WithValue(ctx, contextSettingsOverridesKey, append(overrides, override))
+}
+
+// ContextWithErrorDebug enables ExtErrorDebug for given context (it has no effect in production mode).
+func ContextWithErrorDebug(ctx context.Context) context.Context {
+	return context.WithValue(ctx, contextErrorDebugKey, true)
+}
+
 // ContextErrorValue returns the error value stored in the context.
 func ContextErrorValue(ctx context.Context) (error, bool) {
 	err, ok := ctx.Value(contextErrorKey).(error)
 	return err, ok
 }
 
+// contextErrorDebugValue returns whether error debug information was enabled in the context.
+func contextErrorDebugValue(ctx context.Context) bool {
+	enabled, _ := ctx.Value(contextErrorDebugKey).(bool)
+	return enabled
+}
+
+// contextSettingsOverrides returns settings overrides stored in the context.
+func contextSettingsOverrides(ctx context.Context) []func(*Settings) {
+	overrides, _ := ctx.Value(contextSettingsOverridesKey).([]func(*Settings))
+	return overrides
+}
+
+// ContextErrorIDValue returns the generated error id stored in the context (only in production mode).
+func ContextErrorIDValue(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(contextErrorIDKey).(string)
+	return id, ok
+}
+
 // ContextObjectValue returns the object value stored in the context.
 func ContextObjectValue[T any](ctx context.Context) (T, bool) {
 	val, ok := ctx.Value(contextObjectKey).(T)
@@ -67,6 +163,11 @@ func contextWithErrorValue(ctx context.Context, err error) context.Context {
 	return context.WithValue(ctx, contextErrorKey, err)
 }
 
+// contextWithErrorIDValue adds the error id value to the context.
+func contextWithErrorIDValue(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextErrorIDKey, id)
+}
+
 // contextWithObjectValue adds the object value to the context.
 func contextWithObjectValue(ctx context.Context, obj any) context.Context {
 	return context.WithValue(ctx, contextObjectKey, obj)
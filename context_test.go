@@ -26,7 +26,9 @@ package jayson
 
 import (
 	"context"
+	"errors"
 	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
 	"testing"
 )
 
@@ -64,3 +66,52 @@ func TestCtxObjectValue(t *testing.T) {
 	assert.True(t, ok)
 	assert.Equal(t, 42, obj)
 }
+
+func TestContextWithSettings(t *testing.T) {
+	ctx := context.Background()
+	assert.Empty(t, contextSettingsOverrides(ctx))
+	assert.Equal(t, ctx, ContextWithSettings(ctx, nil))
+
+	first := ContextWithSettings(ctx, func(s *Settings) { s.DefaultErrorMessageKey = "first" })
+	second := ContextWithSettings(first, func(s *Settings) { s.DefaultErrorStatusCodeKey = "second" })
+	other := ContextWithSettings(first, func(s *Settings) { s.DefaultErrorStatusCodeKey = "other" })
+
+	assert.Len(t, contextSettingsOverrides(first), 1)
+	assert.Len(t, contextSettingsOverrides(second), 2)
+	assert.Len(t, contextSettingsOverrides(other), 2)
+
+	jay := New(DefaultSettings()).(*jayson)
+	settings := jay.contextSettings(second)
+	assert.Equal(t, "first", settings.DefaultErrorMessageKey)
+	assert.Equal(t, "second", settings.DefaultErrorStatusCodeKey)
+	assert.Equal(t, "other", jay.contextSettings(other).DefaultErrorStatusCodeKey)
+	assert.Equal(t, "code", jay.settings.DefaultErrorStatusCodeKey)
+
+	// production mode cannot be disabled per request
+	prod := New(Settings{Production: true, DebugErrors: true}).(*jayson)
+	settings = prod.contextSettings(ContextWithSettings(ctx, func(s *Settings) {
+		s.Production = false
+		s.DebugErrors = true
+	}))
+	assert.True(t, settings.Production)
+	assert.False(t, settings.DebugErrors)
+
+	rw := httptest.NewRecorder()
+	prod.Error(ContextWithSettings(ctx, func(s *Settings) { s.Production = false }), rw, errors.New("secret dsn"))
+	assert.NotContains(t, rw.Body.String(), "secret dsn")
+}
+
+func TestContextWithView(t *testing.T) {
+	ctx := context.Background()
+	assert.Empty(t, ContextViewValue(ctx))
+	assert.Equal(t, []string{"admin", "support"}, ContextViewValue(ContextWithView(ctx, "admin", "support")))
+}
+
+func TestContextWithRateLimit(t *testing.T) {
+	ctx := context.Background()
+	_, ok := ContextRateLimitValue(ctx)
+	assert.False(t, ok)
+	rl, ok := ContextRateLimitValue(ContextWithRateLimit(ctx, RateLimit{Limit: 1}))
+	assert.True(t, ok)
+	assert.Equal(t, 1, rl.Limit)
+}
@@ -0,0 +1,96 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Peter Vrba
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package jayson
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"reflect"
+)
+
+// ExtErrorDebug is an extension that renders wrapped error chain and stack trace (if available) to the error object.
+// It is rendered only when enabled by Settings.DebugErrors or by ContextWithErrorDebug, and never in production mode.
+func ExtErrorDebug() Extension {
+	return ExtFunc(
+		nil,
+		func(ctx context.Context, m map[string]any) bool {
+			s := ContextSettingsValue(ctx)
+			if s.Production || !(s.DebugErrors || contextErrorDebugValue(ctx)) {
+				return false
+			}
+			err, ok := ContextErrorValue(ctx)
+			if !ok || err == nil {
+				return false
+			}
+			return setKeyPath(m, s.DefaultErrorDebugKey, newErrorDebug(err))
+		},
+	)
+}
+
+// stackTracer is implemented by errors created by github.com/pkg/errors
+type stackTracer interface {
+	StackTrace() errors.StackTrace
+}
+
+// errorDebug holds debug information about error
+type errorDebug struct {
+	Chain []errorDebugItem `json:"chain"`
+	Stack []string         `json:"stack,omitempty"`
+}
+
+// errorDebugItem is single level of wrapped error chain
+type errorDebugItem struct {
+	Message string `json:"message"`
+	Type    string `json:"type"`
+}
+
+// newErrorDebug inspects error chain and returns debug information
+func newErrorDebug(err error) errorDebug {
+	var (
+		result errorDebug
+		tracer stackTracer
+	)
+
+	for ; err != nil; err = errors.Unwrap(err) {
+		result.Chain = append(result.Chain, errorDebugItem{
+			Message: err.Error(),
+			Type:    reflect.TypeOf(err).String(),
+		})
+
+		// deepest stack trace points closest to the origin of error
+		if st, ok := err.(stackTracer); ok {
+			tracer = st
+		}
+	}
+
+	if tracer != nil {
+		for _, frame := range tracer.StackTrace() {
+			result.Stack = append(result.Stack, fmt.Sprintf("%n %s:%d", frame, frame, frame))
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,83 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Peter Vrba
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package jayson
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestExtErrorDebug(t *testing.T) {
+	base := errors.New("base")
+	wrapped := fmt.Errorf("wrapped: %w", base)
+
+	render := func(s Settings, ctx context.Context) map[string]any {
+		jay := New(s)
+		rw := httptest.NewRecorder()
+		jay.Error(ctx, rw, wrapped, ExtErrorDebug())
+		result := make(map[string]any)
+		assert.NoError(t, json.Unmarshal(rw.Body.Bytes(), &result))
+		return result
+	}
+
+	t.Run("test disabled by default", func(t *testing.T) {
+		assert.NotContains(t, render(DefaultSettings(), context.Background()), "debug")
+	})
+
+	t.Run("test enabled by settings", func(t *testing.T) {
+		s := DefaultSettings()
+		s.DebugErrors = true
+		debug, ok := render(s, context.Background())["debug"].(map[string]any)
+		if !assert.True(t, ok) {
+			return
+		}
+		chain := debug["chain"].([]any)
+		assert.Len(t, chain, 2)
+		assert.Equal(t, map[string]any{"message": "wrapped: base", "type": "*fmt.wrapError"}, chain[0])
+		assert.NotEmpty(t, debug["stack"])
+		assert.Contains(t, debug["stack"].([]any)[0], "TestExtErrorDebug")
+	})
+
+	t.Run("test enabled by context", func(t *testing.T) {
+		assert.Contains(t, render(DefaultSettings(), ContextWithErrorDebug(context.Background())), "debug")
+	})
+
+	t.Run("test never enabled in production", func(t *testing.T) {
+		s := DefaultSettings()
+		s.Production = true
+		s.DebugErrors = true
+		jay := New(s)
+		jay.Logger(zap.NewNop())
+		rw := httptest.NewRecorder()
+		jay.Error(ContextWithErrorDebug(context.Background()), rw, wrapped, ExtErrorDebug())
+		assert.NotContains(t, rw.Body.String(), "chain")
+	})
+}
@@ -0,0 +1,140 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Peter Vrba
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package jayson
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Deprecation describes deprecated endpoint (or response type) and its successor.
+type Deprecation struct {
+	// At is the time when resource was (or will be) deprecated, zero value omits Deprecation header.
+	At time.Time
+	// Sunset is the time when resource will be removed, zero value omits Sunset header.
+	Sunset time.Time
+	// Successor is the url of successor version, empty value omits Link header.
+	Successor string
+	// Hook is called on every hit of deprecated resource (e.g. to count hits), it is optional.
+	Hook func(context.Context, Deprecation)
+}
+
+// Header writes Deprecation, Sunset and Link headers to given header.
+func (d Deprecation) Header(h http.Header) {
+	if !d.At.IsZero() {
+		h.Set("Deprecation", deprecationValue(d.At))
+	}
+	if !d.Sunset.IsZero() {
+		h.Set("Sunset", d.Sunset.UTC().Format(http.TimeFormat))
+	}
+	if d.Successor != "" {
+		h.Add("Link", successorLink(d.Successor))
+	}
+}
+
+// hit calls hook (if set).
+func (d Deprecation) hit(ctx context.Context) {
+	if d.Hook != nil {
+		d.Hook(ctx, d)
+	}
+}
+
+// ExtDeprecated is an extension that writes all deprecation headers and calls deprecation hook.
+func ExtDeprecated(d Deprecation) Extension {
+	return ExtFunc(
+		func(ctx context.Context, w http.ResponseWriter) bool {
+			d.Header(w.Header())
+			d.hit(ctx)
+			return true
+		},
+		nil,
+	)
+}
+
+// ExtDeprecation is an extension that sets Deprecation header (RFC 9745).
+func ExtDeprecation(at time.Time) Extension {
+	return ExtFunc(
+		func(ctx context.Context, w http.ResponseWriter) bool {
+			if at.IsZero() {
+				return false
+			}
+			w.Header().Set("Deprecation", deprecationValue(at))
+			return true
+		},
+		nil,
+	)
+}
+
+// ExtSunset is an extension that sets Sunset header (RFC 8594).
+func ExtSunset(at time.Time) Extension {
+	return ExtFunc(
+		func(ctx context.Context, w http.ResponseWriter) bool {
+			if at.IsZero() {
+				return false
+			}
+			w.Header().Set("Sunset", at.UTC().Format(http.TimeFormat))
+			return true
+		},
+		nil,
+	)
+}
+
+// ExtSuccessorVersion is an extension that adds Link header with successor-version relation.
+func ExtSuccessorVersion(url string) Extension {
+	return ExtFunc(
+		func(ctx context.Context, w http.ResponseWriter) bool {
+			if url == "" {
+				return false
+			}
+			w.Header().Add("Link", successorLink(url))
+			return true
+		},
+		nil,
+	)
+}
+
+// DeprecationMiddleware writes deprecation headers for all requests of route group and calls deprecation hook
+// (request is available in hook context).
+func DeprecationMiddleware(d Deprecation) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			d.Header(w.Header())
+			d.hit(ContextWithRequest(r.Context(), r))
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// deprecationValue returns structured date value of Deprecation header.
+func deprecationValue(at time.Time) string {
+	return fmt.Sprintf("@%d", at.Unix())
+}
+
+// successorLink returns Link header value with successor-version relation.
+func successorLink(url string) string {
+	return fmt.Sprintf(`<%s>; rel="successor-version"`, url)
+}
@@ -0,0 +1,111 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Peter Vrba
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package jayson
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExtDeprecated(t *testing.T) {
+	type legacyUser struct {
+		ID int `json:"id"`
+	}
+
+	at := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	sunset := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	hits := 0
+	j := New(DefaultSettings())
+	assert.NoError(t, j.RegisterResponse(legacyUser{}, ExtDeprecated(Deprecation{
+		At:        at,
+		Sunset:    sunset,
+		Successor: "/v2/users/1",
+		Hook: func(ctx context.Context, d Deprecation) {
+			hits++
+		},
+	})))
+
+	rw := httptest.NewRecorder()
+	j.Response(context.Background(), rw, legacyUser{ID: 1})
+	assert.Equal(t, "@1704067200", rw.Header().Get("Deprecation"))
+	assert.Equal(t, "Wed, 01 Jan 2025 00:00:00 GMT", rw.Header().Get("Sunset"))
+	assert.Equal(t, `</v2/users/1>; rel="successor-version"`, rw.Header().Get("Link"))
+	assert.Equal(t, 1, hits)
+
+	// decoding does not count as hit
+	var u legacyUser
+	assert.NoError(t, j.Decode(context.Background(), strings.NewReader(`{"id": 1}`), &u))
+	assert.Equal(t, 1, hits)
+}
+
+func TestExtDeprecation(t *testing.T) {
+	at := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	rw := httptest.NewRecorder()
+	New(DefaultSettings()).Response(context.Background(), rw, map[string]any{},
+		ExtDeprecation(at),
+		ExtSunset(at.Add(time.Hour)),
+		ExtSuccessorVersion("/v2"),
+	)
+	assert.Equal(t, "@1704067200", rw.Header().Get("Deprecation"))
+	assert.Equal(t, "Mon, 01 Jan 2024 01:00:00 GMT", rw.Header().Get("Sunset"))
+	assert.Equal(t, `</v2>; rel="successor-version"`, rw.Header().Get("Link"))
+
+	rw = httptest.NewRecorder()
+	New(DefaultSettings()).Response(context.Background(), rw, map[string]any{},
+		ExtDeprecation(time.Time{}),
+		ExtSunset(time.Time{}),
+		ExtSuccessorVersion(""),
+	)
+	assert.Empty(t, rw.Header().Get("Deprecation"))
+	assert.Empty(t, rw.Header().Get("Sunset"))
+	assert.Empty(t, rw.Header().Get("Link"))
+}
+
+func TestDeprecationMiddleware(t *testing.T) {
+	var paths []string
+	handler := DeprecationMiddleware(Deprecation{
+		At: time.Unix(100, 0),
+		Hook: func(ctx context.Context, d Deprecation) {
+			r, _ := ContextRequestValue(ctx)
+			paths = append(paths, r.URL.Path)
+		},
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	rw := httptest.NewRecorder()
+	handler.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/v1/users", nil))
+	assert.Equal(t, http.StatusNoContent, rw.Code)
+	assert.Equal(t, "@100", rw.Header().Get("Deprecation"))
+	assert.Empty(t, rw.Header().Get("Sunset"))
+	assert.Equal(t, []string{"/v1/users"}, paths)
+}
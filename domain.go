@@ -29,6 +29,7 @@ import (
 	"errors"
 	"fmt"
 	"go.uber.org/zap"
+	"io"
 	"net/http"
 	"reflect"
 )
@@ -56,14 +57,27 @@ type ExtensionApplyResponseObjectFunc func(context.Context, map[string]any) bool
 // By default, there is a global instance to be used,
 // but for some special purposes you can create your own (multiple servers, different settings, etc.)
 type Jayson interface {
+	// Child returns child instance that inherits registrations (settings can be altered by given functions).
+	Child(...func(*Settings)) Jayson
 	// Debug enables debug mode via zap logger.
 	Debug(*zap.Logger)
+	// Decode decodes JSON request body into given value (reverting key naming strategy).
+	Decode(context.Context, io.Reader, any) error
 	// Error writes error to the client.
 	Error(context.Context, http.ResponseWriter, error, ...Extension)
+	// Logger sets logger used to log errors hidden in production mode.
+	Logger(*zap.Logger)
 	// RegisterError registers extFunc for given error.
 	RegisterError(error, ...Extension) error
 	// RegisterResponse registers extFunc for given response object.
+	// Interface is registered via nil pointer to it, generic family via instantiation with any (e.g. Page[any]{}).
 	RegisterResponse(any, ...Extension) error
+	// RegisterResponseVersion registers transformer that renders response object in the shape of older API version.
+	RegisterResponseVersion(any, string, VersionTransformer) error
+	// RenderError renders error the same way as Error, but returns it instead of writing it to the client.
+	RenderError(context.Context, error, ...Extension) Rendered
+	// RenderResponse renders object the same way as Response, but returns it instead of writing it to the client.
+	RenderResponse(context.Context, any, ...Extension) Rendered
 	// Response writes given object/error to the client.
 	Response(context.Context, http.ResponseWriter, any, ...Extension)
 }
@@ -73,6 +87,14 @@ var (
 	// ErrImproperlyConfigured is error returned when Jayson is improperly configured.
 	ErrImproperlyConfigured = errors.New("jayson: improperly configured")
 	WarnAlreadyRegistered   = fmt.Errorf("%w: already registered", Warning)
+	// ErrInvalidConfig is error returned when declarative config is invalid.
+	ErrInvalidConfig = errors.New("jayson: invalid config")
+	// ErrPreconditionFailed is returned by CheckIfMatch, it is registered with 412 status in every instance.
+	ErrPreconditionFailed = errors.New("precondition failed")
+	// ErrUnknownField is returned when sparse fieldset contains unknown field, it is registered with 400 status.
+	ErrUnknownField = errors.New("unknown field")
+	// ErrRateLimited is returned when request exceeds rate limit, it is registered with 429 status and ExtRateLimit.
+	ErrRateLimited = errors.New("rate limited")
 )
 
 const (
@@ -80,6 +102,18 @@ const (
 	DebugMaxCallerDepth = 255
 )
 
+// objectUnwrapper is an internal interface of extensions that unwrap an object (ExtObjectUnwrap).
+type objectUnwrapper interface {
+	// unwrappedObject returns the unwrapped object
+	unwrappedObject() any
+}
+
+// responseRenderer is an internal interface of responses that render themselves (Batch, Operation).
+type responseRenderer interface {
+	// renderResponse renders response into jayson response writer
+	renderResponse(context.Context, *jayson, *responseWriter, ...Extension) error
+}
+
 // responseTypes is an internal interface that identifies the response types.
 // It is used in ExtObjectUnwrap to identify the type of the object.
 type responseTypes interface {
@@ -92,3 +126,38 @@ type Extended interface {
 	// Extensions returns list of extensions for the error.
 	Extensions() []Extension
 }
+
+// HTTPStatuser is interface for errors that provide their own HTTP status.
+//
+// Errors can describe themselves via HTTPStatuser, ErrorCoder and ErrorFielder without importing jayson.
+// These are looked up along the whole unwrap chain (outer error wins), however
+// extensions registered via RegisterError (and Extended errors) always take precedence over them.
+type HTTPStatuser interface {
+	// HTTPStatus returns HTTP status for the error (zero is ignored).
+	HTTPStatus() int
+}
+
+// ErrorCoder is interface for errors that provide their own application error code.
+type ErrorCoder interface {
+	// ErrorCode returns error code rendered under Settings.DefaultErrorCodeKey (empty is ignored).
+	ErrorCode() string
+}
+
+// FieldErrorer is interface for validation errors that report invalid attributes (attribute to message).
+// In JSON:API mode every invalid attribute is rendered as separate error with source pointer to the attribute.
+type FieldErrorer interface {
+	// FieldErrors returns messages of invalid attributes.
+	FieldErrors() map[string]string
+}
+
+// RateLimiter is interface for errors that carry rate limit result (used by ExtRateLimit).
+type RateLimiter interface {
+	// RateLimit returns rate limit result of the request.
+	RateLimit() RateLimit
+}
+
+// ErrorFielder is interface for errors that provide additional fields to the error object.
+type ErrorFielder interface {
+	// ErrorFields returns fields that are added to the error object.
+	ErrorFields() map[string]any
+}
@@ -0,0 +1,145 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Peter Vrba
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package jayson
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+)
+
+// encoderOptions are options of JSON encoder, they are initialized from settings and can be altered by extensions
+type encoderOptions struct {
+	indent          string
+	escapeHTML      bool
+	int64AsString   bool
+	nilSliceAsEmpty bool
+	fields          []string
+	views           []string
+	keyNaming       KeyNaming
+}
+
+// newEncoderOptions returns encoder options for given settings and request (stored in context)
+func newEncoderOptions(ctx context.Context, s Settings) encoderOptions {
+	result := encoderOptions{
+		escapeHTML:      !s.DisableHTMLEscape,
+		int64AsString:   s.Int64AsString,
+		nilSliceAsEmpty: s.NilSliceAsEmpty,
+		views:           ContextViewValue(ctx),
+		keyNaming:       s.KeyNaming,
+	}
+
+	pretty := s.Pretty
+	if !pretty && s.PrettyQueryParameter != "" {
+		if r, ok := ContextRequestValue(ctx); ok && r.URL != nil {
+			pretty = r.URL.Query().Has(s.PrettyQueryParameter)
+		}
+	}
+	if pretty {
+		result.indent = s.Indent
+	}
+
+	return result
+}
+
+// needsTree returns whether value needs to be converted to tree before encoding
+func (e encoderOptions) needsTree(v any) bool {
+	return e.int64AsString || e.nilSliceAsEmpty || len(e.fields) > 0 || e.keyNaming != nil ||
+		hasViews(reflect.ValueOf(v), 0)
+}
+
+// treeOptions returns options for tree conversion
+func (e encoderOptions) treeOptions() treeOptions {
+	return treeOptions{
+		int64AsString:   e.int64AsString,
+		nilSliceAsEmpty: e.nilSliceAsEmpty,
+		views:           e.views,
+	}
+}
+
+// encode encodes value as JSON with given options
+func (e encoderOptions) encode(v any) ([]byte, error) {
+	if e.needsTree(v) {
+		v = toTree(reflect.ValueOf(v), e.treeOptions(), 0)
+		// keys are renamed first, so fields are given in rendered names
+		if e.keyNaming != nil {
+			v = renameKeys(v, e.keyNaming)
+		}
+		if len(e.fields) > 0 {
+			v = newFieldTree(e.fields).project(v)
+		}
+	}
+
+	var buf bytes.Buffer
+
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(e.escapeHTML)
+	if e.indent != "" {
+		enc.SetIndent("", e.indent)
+	}
+
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// ExtIndent is an extension that sets indentation of encoded JSON (empty indent disables indentation).
+func ExtIndent(indent string) Extension {
+	return extEncoderOptions(func(options *encoderOptions) {
+		options.indent = indent
+	})
+}
+
+// ExtEscapeHTML is an extension that enables/disables escaping of HTML characters in encoded JSON strings.
+func ExtEscapeHTML(escape bool) Extension {
+	return extEncoderOptions(func(options *encoderOptions) {
+		options.escapeHTML = escape
+	})
+}
+
+// ExtInt64AsString is an extension that enables/disables encoding of int64, uint64, int, uint and uintptr values
+// outside of ±(2^53-1) as strings.
+func ExtInt64AsString(enabled bool) Extension {
+	return extEncoderOptions(func(options *encoderOptions) {
+		options.int64AsString = enabled
+	})
+}
+
+// ExtNilSliceAsEmpty is an extension that enables/disables encoding of nil slices as empty arrays.
+func ExtNilSliceAsEmpty(enabled bool) Extension {
+	return extEncoderOptions(func(options *encoderOptions) {
+		options.nilSliceAsEmpty = enabled
+	})
+}
+
+// extEncoderOptions is an extension that alters encoder options of jayson response writer
+func extEncoderOptions(fn func(*encoderOptions)) Extension {
+	return extResponseWriter(func(rw *responseWriter) {
+		fn(&rw.options)
+	})
+}
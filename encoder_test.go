@@ -0,0 +1,110 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Peter Vrba
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package jayson
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type encoderResponse struct {
+	ID    int64    `json:"id"`
+	HTML  string   `json:"html"`
+	Items []string `json:"items"`
+}
+
+func TestEncoderOptions(t *testing.T) {
+	value := encoderResponse{ID: 9007199254740993, HTML: "<b>"}
+
+	render := func(s Settings, ctx context.Context, ext ...Extension) string {
+		return string(New(s).RenderResponse(ctx, value, ext...).Body)
+	}
+
+	t.Run("test defaults", func(t *testing.T) {
+		assert.Equal(t, `{"id":9007199254740993,"html":"\u003cb\u003e","items":null}`+"\n", render(DefaultSettings(), context.Background()))
+	})
+
+	t.Run("test settings", func(t *testing.T) {
+		s := DefaultSettings()
+		s.Pretty = true
+		s.DisableHTMLEscape = true
+		s.Int64AsString = true
+		s.NilSliceAsEmpty = true
+		assert.Equal(t, "{\n  \"id\": \"9007199254740993\",\n  \"html\": \"<b>\",\n  \"items\": []\n}\n", render(s, context.Background()))
+	})
+
+	t.Run("test extensions override settings", func(t *testing.T) {
+		s := DefaultSettings()
+		s.Pretty = true
+		s.Int64AsString = true
+		assert.Equal(t, `{"id":9007199254740993,"html":"<b>","items":[]}`+"\n", render(s, context.Background(),
+			ExtIndent(""),
+			ExtEscapeHTML(false),
+			ExtInt64AsString(false),
+			ExtNilSliceAsEmpty(true),
+		))
+	})
+
+	t.Run("test pretty query parameter", func(t *testing.T) {
+		s := DefaultSettings()
+		s.PrettyQueryParameter = "pretty"
+		s.Indent = "\t"
+
+		r := httptest.NewRequest(http.MethodGet, "/?pretty", nil)
+		assert.Equal(t, "{\n\t\"id\": 9007199254740993,\n\t\"html\": \"\\u003cb\\u003e\",\n\t\"items\": null\n}\n", render(s, ContextWithRequest(context.Background(), r)))
+
+		r = httptest.NewRequest(http.MethodGet, "/", nil)
+		assert.Equal(t, `{"id":9007199254740993,"html":"\u003cb\u003e","items":null}`+"\n", render(s, ContextWithRequest(context.Background(), r)))
+	})
+
+	t.Run("test error", func(t *testing.T) {
+		s := DefaultSettings()
+		s.DisableHTMLEscape = true
+		rendered := New(s).RenderError(context.Background(), NewError("<code>"), ExtIndent(" "))
+		assert.Contains(t, string(rendered.Body), "\n \"message\": \"<code>\"")
+	})
+
+	t.Run("test status codes stay numbers", func(t *testing.T) {
+		s := DefaultSettings()
+		s.Int64AsString = true
+		j := New(s)
+
+		rendered := j.RenderError(context.Background(), NewError("bad").Status(http.StatusBadRequest))
+		assert.JSONEq(t, `{"code":400,"status":"Bad Request","message":"bad","error_code":"bad"}`, string(rendered.Body))
+
+		batch := NewBatch()
+		batch.Add(value, ExtStatus(http.StatusCreated))
+		rendered = j.RenderResponse(context.Background(), batch)
+		assert.Contains(t, string(rendered.Body), `"status":201`)
+		assert.Contains(t, string(rendered.Body), `"id":"9007199254740993"`)
+	})
+
+	t.Run("test extension outside of jayson", func(t *testing.T) {
+		assert.False(t, ExtIndent(" ").ExtendResponseWriter(context.Background(), httptest.NewRecorder()))
+	})
+}
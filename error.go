@@ -0,0 +1,149 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Peter Vrba
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package jayson
+
+import (
+	"maps"
+	"slices"
+)
+
+// NewError creates new structured error with given code.
+//
+// Structured error is immutable, every builder method returns new error that is derived from its template.
+// Derived errors match their templates via errors.Is, and registered extensions of templates
+// are applied to them, so you can register template once and derive per-request instances from it.
+//
+//	ErrUserNotFound = jayson.NewError("user_not_found").Status(http.StatusNotFound)
+//	...
+//	jayson.G().Error(ctx, w, ErrUserNotFound.Detail("user does not exist").Field("id", id).Wrap(err))
+func NewError(code string) *Error {
+	return &Error{
+		code: code,
+	}
+}
+
+// Error is structured error with code, status, detail, fields and cause.
+type Error struct {
+	code     string
+	status   int
+	detail   string
+	fields   map[string]any
+	cause    error
+	ext      []Extension
+	template *Error
+}
+
+// Status returns new error with given HTTP status.
+func (e *Error) Status(status int) *Error {
+	result := e.derive()
+	result.status = status
+	return result
+}
+
+// Detail returns new error with given detail (rendered under Settings.DefaultErrorDetailKey).
+func (e *Error) Detail(detail string) *Error {
+	result := e.derive()
+	result.detail = detail
+	return result
+}
+
+// Field returns new error with additional field added to the error object.
+func (e *Error) Field(key string, value any) *Error {
+	result := e.derive()
+	result.fields[key] = value
+	return result
+}
+
+// Wrap returns new error that wraps given cause.
+func (e *Error) Wrap(cause error) *Error {
+	result := e.derive()
+	result.cause = cause
+	return result
+}
+
+// With returns new error with additional extensions.
+func (e *Error) With(ext ...Extension) *Error {
+	result := e.derive()
+	result.ext = append(result.ext, ext...)
+	return result
+}
+
+// Error returns error message (code and cause if available).
+func (e *Error) Error() string {
+	if e.cause != nil {
+		return e.code + ": " + e.cause.Error()
+	}
+	return e.code
+}
+
+// ErrorCode returns error code (implements ErrorCoder).
+func (e *Error) ErrorCode() string {
+	return e.code
+}
+
+// ErrorFields returns additional fields (implements ErrorFielder).
+func (e *Error) ErrorFields() map[string]any {
+	return e.fields
+}
+
+// Extensions returns extensions (implements Extended).
+func (e *Error) Extensions() []Extension {
+	if e.detail == "" {
+		return e.ext
+	}
+	return append([]Extension{ExtErrorDetail(e.detail)}, e.ext...)
+}
+
+// HTTPStatus returns HTTP status (implements HTTPStatuser).
+func (e *Error) HTTPStatus() int {
+	return e.status
+}
+
+// Is reports whether target is one of the templates this error was derived from.
+func (e *Error) Is(target error) bool {
+	for tmpl := e.template; tmpl != nil; tmpl = tmpl.template {
+		if tmpl == target {
+			return true
+		}
+	}
+	return false
+}
+
+// Unwrap returns wrapped cause.
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// derive returns copy of error that has current error as its template
+func (e *Error) derive() *Error {
+	result := *e
+	result.fields = maps.Clone(e.fields)
+	if result.fields == nil {
+		result.fields = make(map[string]any)
+	}
+	result.ext = slices.Clone(e.ext)
+	result.template = e
+	return &result
+}
@@ -0,0 +1,84 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Peter Vrba
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package jayson
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNewError(t *testing.T) {
+	t.Run("test builder is immutable", func(t *testing.T) {
+		base := NewError("not_found")
+		derived := base.Status(http.StatusNotFound).Detail("detail").Field("id", 1)
+
+		assert.Zero(t, base.HTTPStatus())
+		assert.Nil(t, base.ErrorFields())
+		assert.Equal(t, http.StatusNotFound, derived.HTTPStatus())
+		assert.Equal(t, map[string]any{"id": 1}, derived.ErrorFields())
+		assert.Equal(t, "not_found", derived.ErrorCode())
+	})
+
+	t.Run("test errors.Is matches templates", func(t *testing.T) {
+		tmpl := NewError("not_found").Status(http.StatusNotFound)
+		other := NewError("not_found").Status(http.StatusNotFound)
+		instance := tmpl.Detail("user not found").Wrap(assert.AnError)
+
+		assert.ErrorIs(t, instance, tmpl)
+		assert.ErrorIs(t, instance, assert.AnError)
+		assert.NotErrorIs(t, instance, other)
+		assert.NotErrorIs(t, tmpl, instance)
+		assert.Equal(t, "not_found: "+assert.AnError.Error(), instance.Error())
+	})
+
+	t.Run("test instance shares registered extensions of template", func(t *testing.T) {
+		tmpl := NewError("not_found").Status(http.StatusNotFound)
+
+		jay := New(DefaultSettings())
+		assert.NoError(t, jay.RegisterError(tmpl, ExtHeaderValue("X-Error", "not found")))
+
+		rw := httptest.NewRecorder()
+		jay.Error(context.Background(), rw, tmpl.Detail("user not found").Field("id", 42))
+
+		assert.Equal(t, http.StatusNotFound, rw.Code)
+		assert.Equal(t, []string{"not found"}, rw.Header().Values("X-Error"))
+		assert.JSONEq(t, `{"code":404,"status":"Not Found","message":"not_found","error_code":"not_found","detail":"user not found","id":42}`, rw.Body.String())
+	})
+
+	t.Run("test registered extensions take precedence", func(t *testing.T) {
+		tmpl := NewError("conflict").Status(http.StatusConflict)
+
+		jay := New(DefaultSettings())
+		assert.NoError(t, jay.RegisterError(tmpl, ExtStatus(http.StatusTeapot)))
+
+		rw := httptest.NewRecorder()
+		jay.Error(context.Background(), rw, tmpl.With(ExtHeaderValue("X-Hello", "World")))
+		assert.Equal(t, http.StatusTeapot, rw.Code)
+		assert.Equal(t, "World", rw.Header().Get("X-Hello"))
+	})
+}
@@ -27,9 +27,11 @@ package jayson
 import (
 	"context"
 	"fmt"
+	"math"
 	"net/http"
 	"reflect"
-	"strings"
+	"strconv"
+	"time"
 )
 
 // ExtChain returns an extFunc that chains multiple ext together.
@@ -83,6 +85,20 @@ func ExtConditional(condition Extension, ext ...Extension) Extension {
 	)
 }
 
+// ExtErrorCode is an extension that adds application error code to the error object (under Settings.DefaultErrorCodeKey).
+func ExtErrorCode(code string) Extension {
+	return extSettingsKeyValue(func(s Settings) string {
+		return s.DefaultErrorCodeKey
+	}, code)
+}
+
+// ExtErrorDetail is an extension that adds error detail to the error object (under Settings.DefaultErrorDetailKey).
+func ExtErrorDetail(detail string) Extension {
+	return extSettingsKeyValue(func(s Settings) string {
+		return s.DefaultErrorDetailKey
+	}, detail)
+}
+
 // ExtFirst returns an extFunc that returns the first extFunc that extends the response.
 func ExtFirst(ext ...Extension) Extension {
 	return ExtFunc(
@@ -155,6 +171,20 @@ func ExtHeaderValue(k string, v string) Extension {
 	)
 }
 
+// ExtLocation is an extension that sets Location header of the response.
+func ExtLocation(url string) Extension {
+	return ExtFunc(
+		func(ctx context.Context, w http.ResponseWriter) bool {
+			if url == "" {
+				return false
+			}
+			w.Header().Set("Location", url)
+			return true
+		},
+		nil,
+	)
+}
+
 // ExtNoop does not extend the response or the response object.
 func ExtNoop() Extension {
 	return ExtFunc(nil, nil)
@@ -197,45 +227,37 @@ func ExtObjectKeyValuef(key string, format string, args ...any) Extension {
 // ExtObjectUnwrap is an Extension that converts the given object to the response object.
 // It is useful if you want to add key/values to the response object (by altering it via Extensions).
 // If the object is a struct, it will be converted to a map[string]any.
+// Struct fields are filtered by views stored in context (see ContextWithView).
 func ExtObjectUnwrap(obj any) Extension {
-	// unmarshal object to map[string]any
-	objMap := make(map[string]any)
-
-	// try to inspect struct/map type and add it to the response object
-	val := reflect.ValueOf(obj)
-
-	// if object is a pointer, dereference it
-	for val.Kind() == reflect.Ptr {
-		val = val.Elem()
-	}
-	switch val.Kind() {
-	case reflect.Struct:
-		structToMap(val, objMap)
-	case reflect.Map:
-		for key, value := range val.MapKeys() {
-			objMap[fmt.Sprintf("%v", key)] = val.MapIndex(value).Interface()
-		}
-	default:
-		objMap = nil
-	}
-
-	return extWithResponseTypes(
-		ExtFunc(
+	return &extResponseType{
+		ext: ExtFunc(
 			nil,
 			func(ctx context.Context, m map[string]any) bool {
-				if objMap == nil {
-					s := ContextSettingsValue(ctx)
-					m[s.DefaultUnwrapObjectKey] = obj
-				} else {
-					for k, v := range objMap {
-						m[k] = v
+				// try to inspect struct/map type and add it to the response object
+				val := reflect.ValueOf(obj)
+
+				// if object is a pointer, dereference it
+				for val.Kind() == reflect.Ptr {
+					val = val.Elem()
+				}
+
+				switch val.Kind() {
+				case reflect.Struct:
+					structToMap(val, m, ContextViewValue(ctx))
+				case reflect.Map:
+					for iter := val.MapRange(); iter.Next(); {
+						m[fmt.Sprintf("%v", iter.Key())] = iter.Value().Interface()
 					}
+				default:
+					s := ContextSettingsValue(ctx)
+					setKeyPath(m, s.DefaultUnwrapObjectKey, obj)
 				}
 				return true
 			},
 		),
-		reflect.TypeOf(obj),
-	)
+		types:  []reflect.Type{reflect.TypeOf(obj)},
+		object: obj,
+	}
 }
 
 // isEmptyValue checks if a value is empty (zero value).
@@ -259,42 +281,35 @@ func isEmptyValue(v reflect.Value) bool {
 	return false
 }
 
-// parseJSONTag parses json tag and returns field name, omitempty and skip values.
-func parseJSONTag(tag string, name string) (fieldName string, omitempty bool, skip bool) {
-	// Check for "omitempty" in the tag
-	tagParts := strings.Split(tag, ",")
-	fieldName = tagParts[0]
-	if fieldName == "" {
-		fieldName = name
-	}
-	omitempty = len(tagParts) > 1 && tagParts[1] == "omitempty"
-	skip = len(tagParts) > 0 && tagParts[0] == "-"
-	return fieldName, omitempty, skip
-}
-
-// structToMap converts a struct to a map[string]any.
-func structToMap(val reflect.Value, into map[string]any) {
-	for i := 0; i < val.NumField(); i++ {
-		field := val.Type().Field(i)
-		// skip unexported fields
-		if field.PkgPath != "" {
-			if !field.Anonymous {
-				continue
-			}
-			structToMap(val.Field(i), into)
+// structToMap converts a struct to a map[string]any (using cached json metadata of struct type).
+// Fields that are not visible in given views are skipped, nested values are filtered when encoded.
+func structToMap(val reflect.Value, into map[string]any, views []string) {
+	for _, field := range cachedStructInfo(val.Type()).fields {
+		if !field.visible(views) {
 			continue
 		}
-
-		// parse json tag
-		fieldName, omitEmpty, skip := parseJSONTag(field.Tag.Get("json"), field.Name)
-		if skip {
+		fv, ok := fieldByIndex(val, field.index)
+		if !ok {
 			continue
 		}
-		if omitEmpty && isEmptyValue(val.Field(i)) {
+		if field.omitEmpty && isEmptyValue(fv) {
 			continue
 		}
-
-		into[fieldName] = val.Field(i).Interface()
+		if field.omitZero && fv.IsZero() {
+			continue
+		}
+		if field.asString {
+			if value, ok := stringOptionValue(fv); ok {
+				into[field.name] = value
+				continue
+			}
+		}
+		// embedded unexported struct (with json tag) cannot be interfaced, its exported fields are converted
+		if !fv.CanInterface() {
+			into[field.name] = plainValue(toTree(fv, treeOptions{views: views}, 0))
+			continue
+		}
+		into[field.name] = fv.Interface()
 	}
 }
 
@@ -316,6 +331,20 @@ func ExtOmitObjectKey(keys ...string) Extension {
 	)
 }
 
+// ExtRetryAfter is an extension that sets Retry-After header of the response (in seconds, rounded up).
+func ExtRetryAfter(after time.Duration) Extension {
+	return ExtFunc(
+		func(ctx context.Context, w http.ResponseWriter) bool {
+			if after <= 0 {
+				return false
+			}
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(after.Seconds()))))
+			return true
+		},
+		nil,
+	)
+}
+
 // ExtStatus is an extension that sets the HTTP status code of the response.
 func ExtStatus(status int) Extension {
 	return ExtFunc(
@@ -328,28 +357,27 @@ func ExtStatus(status int) Extension {
 }
 
 // extSettingsKeyValue is an extFunc that adds a single key-value pair to the response object based on the settings.
+// Key can be nested path (e.g. "error.message"), disabled and empty keys are not rendered.
 func extSettingsKeyValue(fn func(s Settings) string, value any) Extension {
 	return ExtFunc(
 		nil,
 		func(ctx context.Context, m map[string]any) bool {
 			s := ContextSettingsValue(ctx)
-			if key := fn(s); key != "" {
-				m[key] = value
-			}
+			setKeyPath(m, fn(s), value)
 			return true
 		},
 	)
 }
 
 // ExtOmitSettingsKey is an extFunc that removes the given keys from the response object based on the settings.
+// Keys can be nested paths (e.g. "error.message").
 func ExtOmitSettingsKey(fn func(settings Settings) []string) Extension {
 	return ExtFunc(
 		nil,
 		func(ctx context.Context, m map[string]any) (result bool) {
 			s := ContextSettingsValue(ctx)
 			for _, key := range fn(s) {
-				if _, ok := m[key]; ok {
-					delete(m, key)
+				if deleteKeyPath(m, key) {
 					result = true
 				}
 			}
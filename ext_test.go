@@ -32,6 +32,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 // extErrorDetail is an extFunc that adds an error detail to the response object.
@@ -290,6 +291,26 @@ func TestExtObjectUnwrap(t *testing.T) {
 		ext.ExtendResponseObject(context.Background(), obj)
 		assert.Equal(t, map[string]any{"key": "hello", "other": "world"}, obj)
 	})
+	t.Run("test embedded unexported with tag", func(t *testing.T) {
+		type inner struct {
+			A int
+		}
+		type tagged struct {
+			inner `json:"in"`
+			B     int64
+		}
+		type taggedPointer struct {
+			*inner `json:"in"`
+		}
+
+		rw := httptest.NewRecorder()
+		New(DefaultSettings()).Response(context.Background(), rw, ExtObjectUnwrap(tagged{inner: inner{A: 1}, B: 2}))
+		assert.JSONEq(t, `{"in":{"A":1},"B":2}`, rw.Body.String())
+
+		rw = httptest.NewRecorder()
+		New(DefaultSettings()).Response(context.Background(), rw, ExtObjectUnwrap(taggedPointer{inner: &inner{A: 1}}))
+		assert.JSONEq(t, `{"in":{"A":1}}`, rw.Body.String())
+	})
 	t.Run("test omitempty", func(t *testing.T) {
 		ext := ExtObjectUnwrap(
 			testStruct{
@@ -305,3 +326,30 @@ func TestExtObjectUnwrap(t *testing.T) {
 		assert.Equal(t, map[string]any{"key": "hello", "other": "world", "OmitEmpty": true}, obj)
 	})
 }
+
+func TestExtLocation(t *testing.T) {
+	rw := httptest.NewRecorder()
+	assert.True(t, ExtLocation("/operations/1").ExtendResponseWriter(context.Background(), rw))
+	assert.Equal(t, "/operations/1", rw.Header().Get("Location"))
+
+	rw = httptest.NewRecorder()
+	assert.False(t, ExtLocation("").ExtendResponseWriter(context.Background(), rw))
+	assert.Empty(t, rw.Header().Get("Location"))
+}
+
+func TestExtRetryAfter(t *testing.T) {
+	for _, item := range []struct {
+		after    time.Duration
+		expected string
+	}{
+		{0, ""},
+		{-time.Second, ""},
+		{time.Second, "1"},
+		{1500 * time.Millisecond, "2"},
+		{time.Minute, "60"},
+	} {
+		rw := httptest.NewRecorder()
+		assert.Equal(t, item.expected != "", ExtRetryAfter(item.after).ExtendResponseWriter(context.Background(), rw))
+		assert.Equal(t, item.expected, rw.Header().Get("Retry-After"), item.after)
+	}
+}
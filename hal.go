@@ -0,0 +1,253 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Peter Vrba
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package jayson
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+)
+
+const (
+	// linksKey is key of HAL links in response object
+	linksKey = "_links"
+	// embeddedKey is key of HAL embedded resources in response object
+	embeddedKey = "_embedded"
+)
+
+// Link is HAL link object.
+type Link struct {
+	Href      string `json:"href"`
+	Templated bool   `json:"templated,omitempty"`
+	Title     string `json:"title,omitempty"`
+	Name      string `json:"name,omitempty"`
+}
+
+// Links are HAL links by relation, multiple links with the same relation are rendered as array.
+// Links are rendered as they are (key naming and sparse fieldsets do not apply to relations).
+type Links map[string]any
+
+// Add adds link for given relation (existing link for relation is turned into array).
+func (l Links) Add(rel string, link Link) {
+	switch existing := l[rel].(type) {
+	case nil:
+		l[rel] = link
+	case Link:
+		l[rel] = []Link{existing, link}
+	case []Link:
+		l[rel] = append(existing, link)
+	}
+}
+
+// MarshalJSON encodes links (HTML characters are escaped by the outer encoder).
+func (l Links) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(map[string]any(l)); err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// RouteResolver resolves URL of named route with given params (e.g. gorilla mux route variables as pairs).
+type RouteResolver interface {
+	// URL returns URL of named route.
+	URL(name string, params ...string) (string, error)
+}
+
+// RouteResolverFunc is function that resolves URL of named route.
+type RouteResolverFunc func(name string, params ...string) (string, error)
+
+// URL calls the function.
+func (f RouteResolverFunc) URL(name string, params ...string) (string, error) {
+	return f(name, params...)
+}
+
+// ExtLink is an extension that adds HAL link with given relation to the response object.
+// When registered for response type, links are added to every item of slice responses (and entry of map responses).
+func ExtLink(rel string, href string) Extension {
+	return newExtLink(rel, func(context.Context, any) (Link, bool) {
+		return Link{Href: href}, true
+	})
+}
+
+// ExtLinkTemplated is an extension that adds templated HAL link (RFC 6570) e.g. "/users{?page,limit}".
+func ExtLinkTemplated(rel string, href string) Extension {
+	return newExtLink(rel, func(context.Context, any) (Link, bool) {
+		return Link{Href: href, Templated: true}, true
+	})
+}
+
+// ExtLinkFunc is an extension that adds HAL link computed from the response object (or item of slice response).
+// Link is not added when object is not of type T or function returns false.
+func ExtLinkFunc[T any](rel string, fn func(ctx context.Context, obj T) (Link, bool)) Extension {
+	return newExtLink(rel, func(ctx context.Context, obj any) (Link, bool) {
+		typed, ok := obj.(T)
+		// pointers (e.g. items of []*T) are dereferenced
+		if value := reflect.ValueOf(obj); !ok && value.Kind() == reflect.Pointer && !value.IsNil() {
+			typed, ok = value.Elem().Interface().(T)
+		}
+		if !ok {
+			return Link{}, false
+		}
+		return fn(ctx, typed)
+	})
+}
+
+// ExtLinkRoute is an extension that adds HAL link to named route resolved by Settings.RouteResolver,
+// route params are computed from the response object (or item of slice response).
+// Link is not added when object is not of type T, resolver is not set or route cannot be resolved.
+func ExtLinkRoute[T any](rel string, route string, params func(ctx context.Context, obj T) []string) Extension {
+	return ExtLinkFunc(rel, func(ctx context.Context, obj T) (Link, bool) {
+		resolver := ContextSettingsValue(ctx).RouteResolver
+		if resolver == nil {
+			return Link{}, false
+		}
+		var args []string
+		if params != nil {
+			args = params(ctx, obj)
+		}
+		href, err := resolver.URL(route, args...)
+		if err != nil {
+			return Link{}, false
+		}
+		return Link{Href: href}, true
+	})
+}
+
+// ExtEmbedded is an extension that adds embedded resource with given relation to the response object.
+func ExtEmbedded(rel string, value any) Extension {
+	return ExtFunc(
+		nil,
+		func(ctx context.Context, m map[string]any) bool {
+			embedded, ok := m[embeddedKey].(map[string]any)
+			if !ok {
+				embedded = make(map[string]any)
+				m[embeddedKey] = embedded
+			}
+			embedded[rel] = value
+			return true
+		},
+	)
+}
+
+// newExtLink returns link extension
+func newExtLink(rel string, resolve func(context.Context, any) (Link, bool)) Extension {
+	return &extLink{rel: rel, resolve: resolve}
+}
+
+// extLink is HAL link extension, it adds link to response object (and to response writer, so raw responses
+// and their slice items can get links too)
+type extLink struct {
+	rel     string
+	resolve func(context.Context, any) (Link, bool)
+}
+
+// responseLink is link registered in response writer, entries links are added to entries of map response
+type responseLink struct {
+	*extLink
+	entries bool
+}
+
+// ExtendResponseWriter registers link in jayson response writer.
+func (e *extLink) ExtendResponseWriter(ctx context.Context, w http.ResponseWriter) bool {
+	rw, ok := w.(*responseWriter)
+	if !ok {
+		return false
+	}
+	rw.links = append(rw.links, responseLink{extLink: e, entries: rw.mapEntries})
+	return true
+}
+
+// ExtendResponseObject adds link to the response object.
+func (e *extLink) ExtendResponseObject(ctx context.Context, m map[string]any) bool {
+	obj, _ := ContextObjectValue[any](ctx)
+	if unwrapped, ok := obj.(objectUnwrapper); ok {
+		obj = unwrapped.unwrappedObject()
+	}
+	link, ok := e.resolve(ctx, obj)
+	if !ok {
+		return false
+	}
+	links, ok := m[linksKey].(Links)
+	if !ok {
+		links = make(Links)
+		m[linksKey] = links
+	}
+	links.Add(e.rel, link)
+	return true
+}
+
+// withLinks converts value to tree and adds links to it (or to its items when value is slice),
+// links registered for element type of map are added to its entries
+func (r *responseWriter) withLinks(ctx context.Context, what any) any {
+	v := reflect.ValueOf(what)
+	tree := toTree(v, r.options.treeOptions(), 0)
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	switch items := tree.(type) {
+	case []any:
+		for i, item := range items {
+			r.addLinks(ctx, item, v.Index(i).Interface(), false)
+		}
+		return tree
+	case *object:
+		if v.Kind() == reflect.Map {
+			for iter := v.MapRange(); iter.Next(); {
+				key, _ := mapKeyString(iter.Key())
+				item, _ := items.Get(key)
+				r.addLinks(ctx, item, iter.Value().Interface(), true)
+			}
+		}
+	}
+	r.addLinks(ctx, tree, what, false)
+	return tree
+}
+
+// addLinks adds resolved links to tree object (links of map entries or the other links)
+func (r *responseWriter) addLinks(ctx context.Context, tree any, obj any, entries bool) {
+	target, ok := tree.(*object)
+	if !ok {
+		return
+	}
+	links := make(Links)
+	for _, link := range r.links {
+		if link.entries != entries {
+			continue
+		}
+		if resolved, ok := link.resolve(ctx, obj); ok {
+			links.Add(link.rel, resolved)
+		}
+	}
+	if len(links) > 0 {
+		target.Set(linksKey, links)
+	}
+}
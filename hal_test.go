@@ -0,0 +1,170 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Peter Vrba
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package jayson
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+type halUser struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestLinks(t *testing.T) {
+	links := make(Links)
+	links.Add("self", Link{Href: "/a"})
+	assert.Equal(t, Link{Href: "/a"}, links["self"])
+	links.Add("self", Link{Href: "/b"})
+	links.Add("self", Link{Href: "/c"})
+	assert.Equal(t, []Link{{Href: "/a"}, {Href: "/b"}, {Href: "/c"}}, links["self"])
+
+	data, err := Links{"search": Link{Href: "/search?a=1&b=2{&q}", Templated: true}}.MarshalJSON()
+	assert.NoError(t, err)
+	assert.Equal(t, `{"search":{"href":"/search?a=1&b=2{&q}","templated":true}}`, string(data))
+}
+
+func TestHAL(t *testing.T) {
+	router := mux.NewRouter()
+	router.Path("/users/{id}").Name("user")
+	router.Path("/users").Name("users")
+
+	resolver := RouteResolverFunc(func(name string, params ...string) (string, error) {
+		route := router.Get(name)
+		if route == nil {
+			return "", errors.New("route not found")
+		}
+		u, err := route.URL(params...)
+		if err != nil {
+			return "", err
+		}
+		return u.String(), nil
+	})
+
+	newJayson := func() Jayson {
+		s := DefaultSettings()
+		s.RouteResolver = resolver
+		j := New(s)
+		Must(j.RegisterResponse(halUser{},
+			ExtLinkRoute("self", "user", func(ctx context.Context, u halUser) []string {
+				return []string{"id", fmt.Sprint(u.ID)}
+			}),
+			ExtLink("collection", "/users"),
+		))
+		return j
+	}
+
+	render := func(j Jayson, what any, ext ...Extension) string {
+		return string(j.RenderResponse(context.Background(), what, ext...).Body)
+	}
+
+	t.Run("test raw struct", func(t *testing.T) {
+		assert.JSONEq(t,
+			`{"id":1,"name":"a","_links":{"self":{"href":"/users/1"},"collection":{"href":"/users"}}}`,
+			render(newJayson(), halUser{ID: 1, Name: "a"}),
+		)
+		assert.JSONEq(t,
+			`{"id":1,"name":"a","_links":{"self":{"href":"/users/1"},"collection":{"href":"/users"}}}`,
+			render(newJayson(), &halUser{ID: 1, Name: "a"}),
+		)
+	})
+
+	t.Run("test slice items", func(t *testing.T) {
+		assert.JSONEq(t,
+			`[{"id":1,"name":"a","_links":{"self":{"href":"/users/1"},"collection":{"href":"/users"}}},{"id":2,"name":"b","_links":{"self":{"href":"/users/2"},"collection":{"href":"/users"}}}]`,
+			render(newJayson(), []halUser{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}}),
+		)
+		assert.JSONEq(t,
+			`[{"id":1,"name":"a","_links":{"self":{"href":"/users/1"},"collection":{"href":"/users"}}}]`,
+			render(newJayson(), []*halUser{{ID: 1, Name: "a"}}),
+		)
+	})
+
+	t.Run("test map entries", func(t *testing.T) {
+		assert.JSONEq(t,
+			`{"a":{"id":1,"name":"a","_links":{"self":{"href":"/users/1"},"collection":{"href":"/users"}}},"b":{"id":2,"name":"b","_links":{"self":{"href":"/users/2"},"collection":{"href":"/users"}}}}`,
+			render(newJayson(), map[string]halUser{"a": {ID: 1, Name: "a"}, "b": {ID: 2, Name: "b"}}),
+		)
+		assert.JSONEq(t,
+			`{"1":{"id":1,"name":"a","_links":{"self":{"href":"/users/1"},"collection":{"href":"/users"}}},"2":null}`,
+			render(newJayson(), &map[int]*halUser{1: {ID: 1, Name: "a"}, 2: nil}),
+		)
+	})
+
+	t.Run("test map object", func(t *testing.T) {
+		assert.JSONEq(t,
+			`{"id":1,"_links":{"self":{"href":"/x"}}}`,
+			render(newJayson(), map[string]any{"id": 1}, ExtLink("self", "/x")),
+		)
+		// links registered for element type go to entries, passed links to the map itself
+		assert.JSONEq(t,
+			`{"a":{"id":1,"name":"a","_links":{"self":{"href":"/users/1"},"collection":{"href":"/users"}}},"_links":{"search":{"href":"/users{?q}","templated":true}}}`,
+			render(newJayson(), map[string]halUser{"a": {ID: 1, Name: "a"}}, ExtLinkTemplated("search", "/users{?q}")),
+		)
+	})
+
+	t.Run("test object unwrap", func(t *testing.T) {
+		assert.JSONEq(t,
+			`{"id":1,"name":"a","_links":{"self":{"href":"/users/1"},"collection":{"href":"/users"},"search":{"href":"/users{?q}","templated":true}},"_embedded":{"friends":[{"id":2,"name":"b"}]}}`,
+			render(newJayson(), ExtObjectUnwrap(halUser{ID: 1, Name: "a"}),
+				ExtLinkTemplated("search", "/users{?q}"),
+				ExtEmbedded("friends", []halUser{{ID: 2, Name: "b"}}),
+			),
+		)
+	})
+
+	t.Run("test unresolved", func(t *testing.T) {
+		j := New(DefaultSettings())
+		assert.JSONEq(t, `{"id":1,"name":"a"}`, render(j, halUser{ID: 1, Name: "a"}, ExtLinkRoute[halUser]("self", "user", nil)))
+
+		j = newJayson()
+		assert.JSONEq(t,
+			`{"id":1,"name":"a","_links":{"self":{"href":"/users/1"},"collection":{"href":"/users"}}}`,
+			render(j, halUser{ID: 1, Name: "a"}, ExtLinkRoute[halUser]("unknown", "unknown", nil), ExtLinkRoute[int]("other", "users", nil)),
+		)
+	})
+
+	t.Run("test key naming and fields", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/?fields=id", nil)
+		j := newJayson()
+		rendered := j.RenderResponse(ContextWithRequest(r.Context(), r), halUser{ID: 1, Name: "a"},
+			ExtFieldsQuery("fields"),
+			ExtKeyNaming(KeyNamingKebab),
+			ExtLink("next_page", "/users?page=2"),
+		)
+		assert.JSONEq(t,
+			`{"id":1,"_links":{"self":{"href":"/users/1"},"collection":{"href":"/users"},"next_page":{"href":"/users?page=2"}}}`,
+			string(rendered.Body),
+		)
+	})
+}
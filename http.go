@@ -26,7 +26,9 @@ package jayson
 
 import (
 	"bytes"
+	"context"
 	"net/http"
+	"strconv"
 )
 
 // applyHeader applies headers from src to dst
@@ -38,6 +40,14 @@ func applyHeader(dst http.Header, src http.Header) {
 	}
 }
 
+// Rendered is rendered response (or error) with status, headers and encoded body.
+// It is useful outside of HTTP (websocket frames, message queues, audit logs, ...).
+type Rendered struct {
+	Status int
+	Header http.Header
+	Body   []byte
+}
+
 // newResponseWriter creates a new response writer
 // this is used for compatibility reasons and for various quirks of the http.ResponseWriter
 func newResponseWriter(statusCode int) *responseWriter {
@@ -45,13 +55,25 @@ func newResponseWriter(statusCode int) *responseWriter {
 		header:     make(http.Header),
 		buffer:     bytes.Buffer{},
 		statusCode: statusCode,
+		options:    encoderOptions{escapeHTML: true},
 	}
 }
 
 type responseWriter struct {
-	header     http.Header
-	buffer     bytes.Buffer
-	statusCode int
+	header      http.Header
+	buffer      bytes.Buffer
+	statusCode  int
+	options     encoderOptions
+	compression compressionOptions
+	etag        bool
+	links       []responseLink
+	versions    []responseVersion
+	transforms  []transformFunc
+	jsonAPI     bool
+	// mapEntries is set while extensions resolved by element type of map response are applied
+	mapEntries bool
+	// head omits body of response to HEAD request (headers and Content-Length are kept)
+	head bool
 }
 
 // Header returns the header map
@@ -64,14 +86,83 @@ func (r *responseWriter) Write(bytes []byte) (int, error) {
 	return r.buffer.Write(bytes)
 }
 
+// encode encodes value as JSON into buffer (previous content of buffer is discarded)
+func (r *responseWriter) encode(v any) error {
+	data, err := r.options.encode(v)
+	if err != nil {
+		return err
+	}
+	r.buffer = bytes.Buffer{}
+	r.buffer.Write(data)
+	return nil
+}
+
 // WriteHeader writes status code to writer
 func (r *responseWriter) WriteHeader(statusCode int) {
 	r.statusCode = statusCode
 }
 
+// Rendered returns copy of collected response (body of response to HEAD request is omitted)
+func (r *responseWriter) Rendered() Rendered {
+	result := Rendered{
+		Status: r.statusCode,
+		Header: r.header.Clone(),
+		Body:   bytes.Clone(r.buffer.Bytes()),
+	}
+	if r.head {
+		r.setContentLength(result.Header, len(result.Body))
+		result.Body = nil
+	}
+	return result
+}
+
+// extResponseWriter is an extension that alters internal jayson response writer (other writers are ignored)
+func extResponseWriter(fn func(*responseWriter)) Extension {
+	return ExtFunc(
+		func(ctx context.Context, w http.ResponseWriter) bool {
+			rw, ok := w.(*responseWriter)
+			if !ok {
+				return false
+			}
+			fn(rw)
+			return true
+		},
+		nil,
+	)
+}
+
 // WriteTo writes the response to the given http.ResponseWriter
+// body is compressed when compression is enabled and negotiated with client
 func (r *responseWriter) WriteTo(w http.ResponseWriter) {
+	if compressed, ok := r.compression.compress(r.header, r.statusCode, r.buffer.Bytes()); ok {
+		r.buffer = bytes.Buffer{}
+		r.buffer.Write(compressed)
+	}
+	// response to HEAD request has headers (with length of body that would be sent) but no body
+	if r.head {
+		r.setContentLength(r.header, r.buffer.Len())
+	}
 	applyHeader(w.Header(), r.header)
 	w.WriteHeader(r.statusCode)
-	_, _ = r.buffer.WriteTo(w)
+	if !r.head {
+		_, _ = r.buffer.WriteTo(w)
+	}
+}
+
+// setContentLength sets Content-Length to given header (responses that cannot have body are skipped)
+func (r *responseWriter) setContentLength(header http.Header, length int) {
+	if bodyAllowed(r.statusCode) {
+		header.Set("Content-Length", strconv.Itoa(length))
+	}
+}
+
+// bodyAllowed returns whether response with given status can have body
+func bodyAllowed(status int) bool {
+	return status >= http.StatusOK && status != http.StatusNoContent && status != http.StatusNotModified
+}
+
+// isHeadRequest returns whether request stored in context is HEAD request
+func isHeadRequest(ctx context.Context) bool {
+	r, ok := ContextRequestValue(ctx)
+	return ok && r.Method == http.MethodHead
 }
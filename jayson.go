@@ -25,14 +25,14 @@
 package jayson
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 	"net/http"
 	"reflect"
+	"slices"
+	"sync"
 )
 
 // New instantiates custom jayson instance. Usually you don't need to use it, since there is a _g instance.
@@ -40,22 +40,62 @@ func New(settings Settings) Jayson {
 	// validate settings
 	settings.Validate()
 
-	return &jayson{
-		settings:              settings,
-		registryErrors:        newRegistry[error](),
-		registryResponseTypes: newRegistry[reflect.Type](),
+	result := &jayson{
+		settings:                 settings,
+		registryErrors:           newRegistry[error](),
+		registryConfigErrors:     newRegistry[error](),
+		registryResponseTypes:    newRegistry[reflect.Type](),
+		registryResponseVersions: newRegistry[reflect.Type](),
 	}
+
+	// register errors provided by jayson
+	Must(
+		result.RegisterError(ErrPreconditionFailed, ExtStatus(http.StatusPreconditionFailed)),
+		result.RegisterError(ErrUnknownField, ExtStatus(http.StatusBadRequest)),
+		result.RegisterError(ErrRateLimited, ExtStatus(http.StatusTooManyRequests), ExtRateLimit()),
+	)
+
+	return result
 }
 
 // jayson implements Jayson interface
 type jayson struct {
+	parent   *jayson
 	debug    *zap.Logger
+	logger   *zap.Logger
 	settings Settings
 
 	// registry for errors
 	registryErrors *registry[error]
+	// registry for errors configured by Config.Apply (replaced on every Apply, applied after registryErrors)
+	registryConfigErrors *registry[error]
 	// registry for response types
 	registryResponseTypes *registry[reflect.Type]
+	// registry for version transformers of response types
+	registryResponseVersions *registry[reflect.Type]
+
+	// cache of matched interfaces and generic families of response types
+	matchCache sync.Map
+}
+
+// Child returns child instance that inherits registrations of this instance.
+// Child starts with copy of parent settings that can be altered by given functions.
+// Registrations in child override the parent ones, and parent registrations done later are still visible.
+func (j *jayson) Child(override ...func(*Settings)) Jayson {
+	settings := j.settings
+	for _, fn := range override {
+		fn(&settings)
+	}
+	settings.Validate()
+
+	return &jayson{
+		parent:                   j,
+		settings:                 settings,
+		registryErrors:           newChildRegistry(j.registryErrors),
+		registryConfigErrors:     newChildRegistry(j.registryConfigErrors),
+		registryResponseTypes:    newChildRegistry(j.registryResponseTypes),
+		registryResponseVersions: newChildRegistry(j.registryResponseVersions),
+	}
 }
 
 // Debug enables debug mode via zap logger
@@ -63,28 +103,62 @@ func (j *jayson) Debug(logger *zap.Logger) {
 	j.debug = logger
 }
 
+// Logger sets logger used to log errors hidden in production mode
+func (j *jayson) Logger(logger *zap.Logger) {
+	j.logger = logger
+}
+
 // Error writes error response to the client
 func (j *jayson) Error(ctx context.Context, rw http.ResponseWriter, err error, override ...Extension) {
 	if err == nil {
 		return
 	}
 
+	// write to a response writer
+	j.renderError(ctx, err, override...).WriteTo(rw)
+}
+
+// RenderError renders error response without writing it to the client
+// when err is nil, zero value is returned
+func (j *jayson) RenderError(ctx context.Context, err error, override ...Extension) Rendered {
+	if err == nil {
+		return Rendered{}
+	}
+
+	return j.renderError(ctx, err, override...).Rendered()
+}
+
+// renderError renders error response into internal response writer
+func (j *jayson) renderError(ctx context.Context, err error, override ...Extension) *responseWriter {
 	// get error extensions
-	ext, _ := j.getErrorExtensions(err, override...)
+	ext, known := j.getErrorExtensions(err, override...)
+
+	// resolve settings (with context overrides)
+	settings := j.contextSettings(ctx)
 
 	// prepare context
 	ctx = contextWithErrorValue(
-		contextWithSettingsValue(ctx, j.settings),
+		contextWithSettingsValue(ctx, settings),
 		err,
 	)
 
-	// rwInternal
-	obj := map[string]any{
-		j.settings.DefaultErrorMessageKey: err.Error(),
+	// in production mode we generate error id beforehand, so extensions can use it
+	var errorID string
+	if settings.Production {
+		errorID = newErrorID()
+		ctx = contextWithErrorIDValue(ctx, errorID)
 	}
 
+	// rwInternal
+	obj := make(map[string]any)
+	setKeyPath(obj, settings.DefaultErrorMessageKey, err.Error())
+
 	// prepare internal response writer
-	rwInternal := newResponseWriter(j.settings.DefaultErrorStatus)
+	rwInternal := newResponseWriter(settings.DefaultErrorStatus)
+	rwInternal.options = newEncoderOptions(ctx, settings)
+	rwInternal.compression = newCompressionOptions(ctx, settings)
+	rwInternal.jsonAPI = settings.JSONAPI
+	rwInternal.head = isHeadRequest(ctx)
 
 	// prepare executor
 	exec := newExecutor(ext)
@@ -94,30 +168,45 @@ func (j *jayson) Error(ctx context.Context, rw http.ResponseWriter, err error, o
 
 	// now add additional properties to object
 	// handle status code and text
-	obj[j.settings.DefaultErrorStatusCodeKey] = rwInternal.statusCode
+	setKeyPath(obj, settings.DefaultErrorStatusCodeKey, rwInternal.statusCode)
 
 	// handle status text
 	if text := http.StatusText(rwInternal.statusCode); text != "" {
-		obj[j.settings.DefaultErrorStatusTextKey] = text
+		setKeyPath(obj, settings.DefaultErrorStatusTextKey, text)
+	}
+
+	// hide error details of unknown errors and server errors in production mode
+	if settings.Production && (!known || rwInternal.statusCode >= http.StatusInternalServerError) {
+		setKeyPath(obj, settings.DefaultErrorMessageKey, settings.productionErrorMessage(rwInternal.statusCode))
+		setKeyPath(obj, settings.DefaultErrorIDKey, errorID)
+
+		j.getLogger().Error("jayson: hidden error",
+			zap.String("error_id", errorID),
+			zap.Int("status", rwInternal.statusCode),
+			zap.Error(err),
+		)
 	}
 
 	// now extend object
 	exec.ExtendResponseObject(ctx, obj)
 
-	// clear buffer here
-	rwInternal.buffer = bytes.Buffer{}
-	rwInternal.Header()["Content-Type"] = []string{"application/json"}
+	rwInternal.Header()["Content-Type"] = []string{rwInternal.contentType()}
+
+	// JSON:API errors document is built from error object
+	var body any = obj
+	if rwInternal.jsonAPI {
+		body = rwInternal.jsonAPIErrors(err, obj, settings)
+	}
 
-	// now write JSON value
-	if err := json.NewEncoder(rwInternal).Encode(obj); err != nil {
+	// now write JSON value (buffer is cleared)
+	if err := rwInternal.encode(body); err != nil {
 		// if we can't write JSON, we will panic
 		// it's fine now
 		// FIXME: we should log this
 		panic(err)
 	}
 
-	// write to a response writer
-	rwInternal.WriteTo(rw)
+	return rwInternal
 }
 
 // RegisterError registers extFunc for given error
@@ -134,11 +223,13 @@ func (j *jayson) RegisterError(err error, ext ...Extension) error {
 		panic(fmt.Errorf("%w: error is nil", ErrImproperlyConfigured))
 	}
 
-	// check for Extended interface
-	if extended, ok := err.(Extended); ok {
-		ext = append(extended.Extensions(), ext...)
+	// errors are stored as map keys
+	if !reflect.TypeOf(err).Comparable() {
+		return fmt.Errorf("%w: error %T is not comparable", ErrImproperlyConfigured, err)
 	}
 
+	// Extended errors are not merged here, their extensions are applied on every lookup (see getErrorExtensions)
+
 	// if Any, we will Register ext for any error
 	if errors.Is(err, Any) {
 		j.registryErrors.AddShared(ext...)
@@ -150,6 +241,10 @@ func (j *jayson) RegisterError(err error, ext ...Extension) error {
 
 // RegisterResponse registers extFunc for given object
 func (j *jayson) RegisterResponse(what any, extensions ...Extension) error {
+	// nil is rendered as NoContent
+	if what == nil {
+		what = NoContent
+	}
 
 	// log caller
 	j.debugLogMethod("RegisterResponse", func() []zap.Field {
@@ -166,34 +261,77 @@ func (j *jayson) RegisterResponse(what any, extensions ...Extension) error {
 	}
 
 	// register response type
-	return j.registryResponseTypes.Register(reflect.TypeOf(what), extensions)
+	return j.registryResponseTypes.Register(responseType(what), extensions)
 }
 
 // Response writes response to the client
 func (j *jayson) Response(ctx context.Context, rw http.ResponseWriter, what any, override ...Extension) {
+	j.renderResponse(ctx, what, override...).WriteTo(rw)
+}
+
+// RenderResponse renders response without writing it to the client
+func (j *jayson) RenderResponse(ctx context.Context, what any, override ...Extension) Rendered {
+	return j.renderResponse(ctx, what, override...).Rendered()
+}
+
+// renderResponse renders response into internal response writer
+func (j *jayson) renderResponse(ctx context.Context, what any, override ...Extension) *responseWriter {
+	// resolve settings (with context overrides)
+	settings := j.contextSettings(ctx)
+
+	// nil is rendered without body
+	if what == nil {
+		what = NoContent
+	}
+
 	// add object value to the context along with settings
 	ctx = contextWithObjectValue(
-		contextWithSettingsValue(ctx, j.settings),
+		contextWithSettingsValue(ctx, settings),
 		what,
 	)
 
 	// rwInternal is a response writer that will be used to collect response
-	rwInternal := newResponseWriter(j.settings.DefaultResponseStatus)
+	rwInternal := newResponseWriter(settings.DefaultResponseStatus)
+	rwInternal.options = newEncoderOptions(ctx, settings)
+	rwInternal.compression = newCompressionOptions(ctx, settings)
+	rwInternal.jsonAPI = settings.JSONAPI
+	rwInternal.etag = settings.ETag
+	rwInternal.head = isHeadRequest(ctx)
+
+	rwInternal.options.fields = requestFields(ctx, settings.FieldsQueryParameter)
 
 	// if what is an override, we will be having object automatically
-	if extension, ok := what.(Extension); ok {
-		j.responseExtension(ctx, rwInternal, what, extension, override...)
-	} else {
-		j.responseRaw(ctx, rwInternal, what, override...)
+	var err error
+	switch typed := what.(type) {
+	case responseRenderer:
+		err = typed.renderResponse(ctx, j, rwInternal, override...)
+	case Extension:
+		err = j.responseExtension(ctx, rwInternal, what, typed, override...)
+	default:
+		err = j.responseRaw(ctx, rwInternal, what, override...)
+	}
+
+	// invalid request (such as unknown field in sparse fieldset) is rendered as error
+	if err != nil {
+		return j.renderError(ctx, err)
+	}
+
+	// response without body has no content type (nor ETag)
+	if _, ok := what.(noContent); ok {
+		return rwInternal
 	}
 
 	// set content type
-	rwInternal.Header()["Content-Type"] = []string{"application/json"}
-	rwInternal.WriteTo(rw)
+	rwInternal.Header()["Content-Type"] = []string{rwInternal.contentType()}
+
+	// ETag, Last-Modified and conditional GET
+	applyConditional(ctx, rwInternal, what)
+
+	return rwInternal
 }
 
 // responseExtension is called when `what` is an extension
-func (j *jayson) responseExtension(ctx context.Context, rw *responseWriter, what any, whatExt Extension, override ...Extension) {
+func (j *jayson) responseExtension(ctx context.Context, rw *responseWriter, what any, whatExt Extension, override ...Extension) error {
 	// create object
 	obj := make(map[string]any)
 
@@ -232,17 +370,34 @@ func (j *jayson) responseExtension(ctx context.Context, rw *responseWriter, what
 	// extend object
 	exec.ExtendResponseObject(ctx, obj)
 
-	// now clear buffer if someone mistakenly wrote to it
-	rw.buffer = bytes.Buffer{}
+	var value any = obj
+	if !rw.jsonAPI {
+		// fields are projected from the shape of requested version, so they are validated against it
+		if versioned, ok := rw.versioned(ctx, obj); ok {
+			value, types = versioned, nil
+		}
+	}
+
+	// fields are known either in object or in unwrapped types
+	if err := rw.options.validateFields(value, types...); err != nil {
+		return err
+	}
+
+	// JSON:API document is built after validation, since it clears sparse fieldset (it is applied to attributes)
+	if rw.jsonAPI {
+		value = rw.jsonAPIUnwrapDocument(what, obj)
+	}
 
-	// json marshal object
-	if err := json.NewEncoder(rw).Encode(obj); err != nil {
+	// json marshal object (buffer is cleared if someone mistakenly wrote to it)
+	if err := rw.encode(value); err != nil {
 		panic(err)
 	}
+
+	return nil
 }
 
 // responseRaw is called when `what` is not an extension
-func (j *jayson) responseRaw(ctx context.Context, rw *responseWriter, what any, override ...Extension) {
+func (j *jayson) responseRaw(ctx context.Context, rw *responseWriter, what any, override ...Extension) error {
 	ext, ok := j.getResponseTypeExtensions(reflect.TypeOf(what), override...)
 	_ = ok
 	// prepare executor with ext (first what we found in registered types, then what is passed in function)
@@ -251,38 +406,101 @@ func (j *jayson) responseRaw(ctx context.Context, rw *responseWriter, what any,
 	// now extend response, no object here
 	exec.ExtendResponseWriter(ctx, rw)
 
-	// now clear buffer if someone mistakenly wrote to it
-	rw.buffer = bytes.Buffer{}
+	// object extensions cannot alter raw value, transforms can
+	what = rw.transform(ctx, what)
+
+	// links registered for response type are added to object (or to every item of slice)
+	value, validated := what, what
+	if !rw.jsonAPI {
+		if len(rw.links) > 0 {
+			value = rw.withLinks(ctx, what)
+		}
+		// fields are projected from the shape of requested version, so they are validated against it
+		if versioned, ok := rw.versioned(ctx, value); ok {
+			value, validated = versioned, versioned
+		}
+	}
+
+	if err := rw.options.validateFields(validated); err != nil {
+		return err
+	}
+
+	// JSON:API document is built after validation, since it clears sparse fieldset (it is applied to attributes)
+	if rw.jsonAPI {
+		value = rw.jsonAPIDocument(what)
+	}
+
+	// now json encode object (buffer is cleared if someone mistakenly wrote to it)
+	if err := rw.encode(value); err != nil {
+		panic(err)
+	}
+
+	return nil
+}
+
+// nestedContext returns context for rendering of nested responses and errors (Batch entries, ...),
+// nested values do not inspect request (conditional requests and sparse fieldsets apply to the whole document)
+func nestedContext(ctx context.Context) context.Context {
+	return ContextWithRequest(ctx, nil)
+}
 
-	// now json encode object
-	if err := json.NewEncoder(rw).Encode(what); err != nil {
+// renderDocument encodes document of self-rendered response (Batch, Operation) into response writer,
+// registered extensions of response type and overrides are applied to response writer
+func (j *jayson) renderDocument(ctx context.Context, rw *responseWriter, what any, doc any, override ...Extension) {
+	ext, _ := j.getResponseTypeExtensions(reflect.TypeOf(what), override...)
+	newExecutor(ext).ExtendResponseWriter(ctx, rw)
+
+	// document itself is plain JSON
+	rw.options.fields = nil
+	rw.jsonAPI = false
+
+	if err := rw.encode(doc); err != nil {
 		panic(err)
 	}
 }
 
 // getErrorExtensions returns all extensions for given error
 // it also adds extensions for all parent errors and Any
+// it returns true if any error in chain is registered or describes itself (Extended, HTTPStatuser, ...)
 // even when false is returned, extensions are returned
 func (j *jayson) getErrorExtensions(err error, override ...Extension) ([]Extension, bool) {
 	var (
-		result []Extension
-		found  bool
+		result    []Extension
+		described []Extension
+		found     bool
 	)
 
 	for err != nil {
+		// errors that describe themselves via interfaces
+		if ext := errorInterfaceExtensions(err); len(ext) > 0 {
+			described = append(ext, described...)
+			found = true
+		}
+
 		if extended, ok := err.(Extended); ok {
 			result = append(extended.Extensions(), result...)
+			found = true
 		}
 
-		// we prepend errors
-		if ext, ok := j.registryErrors.Get(err); ok {
-			result = append(ext, result...)
-			found = true
+		// we prepend errors (structured errors are also looked up by their templates)
+		for _, key := range errorRegistryKeys(err) {
+			ext, ok := j.registryErrors.Get(key)
+			if configured, configuredOk := j.registryConfigErrors.Get(key); configuredOk {
+				ext = append(slices.Clone(ext), configured...)
+				ok = true
+			}
+			if ok {
+				result = append(ext, result...)
+				found = true
+			}
 		}
 
 		err = errors.Unwrap(err)
 	}
 
+	// interface extensions go first, so registered extensions take precedence
+	result = append(described, result...)
+
 	// add shared extensions
 	result = j.registryErrors.WithShared(result...)
 	// and append override
@@ -291,34 +509,109 @@ func (j *jayson) getErrorExtensions(err error, override ...Extension) ([]Extensi
 	return result, found
 }
 
+// errorRegistryKeys returns keys to look up registered extensions for given error (most specific first)
+// uncomparable errors cannot be registered, so no keys are returned for them
+func errorRegistryKeys(err error) []error {
+	if !reflect.TypeOf(err).Comparable() {
+		return nil
+	}
+	keys := []error{err}
+	if structured, ok := err.(*Error); ok && structured != nil {
+		for tmpl := structured.template; tmpl != nil; tmpl = tmpl.template {
+			keys = append(keys, tmpl)
+		}
+	}
+	return keys
+}
+
+// errorInterfaceExtensions returns extensions for error that implements HTTPStatuser, ErrorCoder or ErrorFielder
+// only given error is inspected (not its chain)
+func errorInterfaceExtensions(err error) []Extension {
+	var result []Extension
+
+	if statuser, ok := err.(HTTPStatuser); ok {
+		if status := statuser.HTTPStatus(); status != 0 {
+			result = append(result, ExtStatus(status))
+		}
+	}
+	if coder, ok := err.(ErrorCoder); ok {
+		if code := coder.ErrorCode(); code != "" {
+			result = append(result, ExtErrorCode(code))
+		}
+	}
+	if fielder, ok := err.(ErrorFielder); ok {
+		if fields := fielder.ErrorFields(); len(fields) > 0 {
+			result = append(result, ExtFunc(nil, func(ctx context.Context, m map[string]any) bool {
+				for k, v := range fields {
+					m[k] = v
+				}
+				return true
+			}))
+		}
+	}
+
+	return result
+}
+
 // getResponseTypeExtensionsBare returns all extensions for given response type
 // no other extensions are added (no default, no overrides
 func (j *jayson) getResponseTypeExtensionsBare(what reflect.Type, level int) ([]Extension, bool) {
-	var (
-		ext []Extension
-		ok  bool
-	)
+	return j.resolveResponseType(j.registryResponseTypes, j.registryResponseTypes.Get, what, level)
+}
 
+// resolveResponseType resolves extensions of given type by get function, precedence is:
+// exact type, pointer/elem type, element of slice/array/map, implemented interface, generic family
+func (j *jayson) resolveResponseType(
+	reg *registry[reflect.Type],
+	get func(reflect.Type) ([]Extension, bool),
+	what reflect.Type,
+	level int,
+) ([]Extension, bool) {
 	// check exact type
-	if ext, ok = j.registryResponseTypes.Get(what); !ok {
-		switch what.Kind() {
-		case reflect.Ptr:
-			// check if we have extension for pointer type
-			if ext, ok = j.registryResponseTypes.Get(what); !ok && level == 0 {
-
-				// if we are on zero level, we will try to Get extension for Elem
-				return j.getResponseTypeExtensionsBare(what.Elem(), level+1)
+	if ext, ok := get(what); ok {
+		return ext, true
+	}
+
+	switch what.Kind() {
+	case reflect.Ptr:
+		// if we are on zero level, we will try to Get extension for Elem
+		if level == 0 {
+			if ext, ok := j.resolveResponseType(reg, get, what.Elem(), level+1); ok {
+				return ext, true
 			}
-		case reflect.Slice, reflect.Array:
-			ext, ok = j.getResponseTypeExtensionsBare(what.Elem(), 0)
-		default:
-			if ext, ok = j.registryResponseTypes.Get(what); !ok && level == 0 {
-				return j.getResponseTypeExtensionsBare(reflect.PointerTo(what), level+1)
+		}
+	case reflect.Slice, reflect.Array:
+		if ext, ok := j.resolveResponseType(reg, get, what.Elem(), 0); ok {
+			return ext, true
+		}
+	case reflect.Map:
+		if ext, ok := j.resolveResponseType(reg, get, what.Elem(), 0); ok {
+			return mapEntryExtensions(ext), true
+		}
+	default:
+		// if we are on zero level, we will try to Get extension for pointer type
+		if level == 0 {
+			if ext, ok := get(reflect.PointerTo(what)); ok {
+				return ext, true
 			}
 		}
 	}
 
-	return ext, ok
+	// registered interfaces and generic families are matched last
+	if level == 0 {
+		return j.matchResponseType(reg, get, what)
+	}
+
+	return nil, false
+}
+
+// mapEntryExtensions marks extensions resolved by element type of map, links and versions they add
+// describe entries of the map (and not the map itself)
+func mapEntryExtensions(ext []Extension) []Extension {
+	result := make([]Extension, 0, len(ext)+2)
+	result = append(result, extResponseWriter(func(rw *responseWriter) { rw.mapEntries = true }))
+	result = append(result, ext...)
+	return append(result, extResponseWriter(func(rw *responseWriter) { rw.mapEntries = false }))
 }
 
 // getResponseTypeExtensions returns all extensions for given response type
@@ -333,6 +626,12 @@ func (j *jayson) getResponseTypeExtensions(what reflect.Type, override ...Extens
 	// check exact type
 	ext, ok = j.getResponseTypeExtensionsBare(what, 0)
 
+	// version transformers are registered separately, so they survive re-registration of response type
+	if versions, found := j.resolveResponseType(j.registryResponseVersions, j.registryResponseVersions.GetAll, what, 0); found {
+		ext = append(ext, versions...)
+		ok = true
+	}
+
 	ext = j.registryResponseTypes.WithShared(ext...)
 
 	ext = append(ext, override...)
@@ -340,6 +639,36 @@ func (j *jayson) getResponseTypeExtensions(what reflect.Type, override ...Extens
 	return ext, ok
 }
 
+// contextSettings returns instance settings with overrides from context applied
+func (j *jayson) contextSettings(ctx context.Context) Settings {
+	overrides := contextSettingsOverrides(ctx)
+	if len(overrides) == 0 {
+		return j.settings
+	}
+
+	settings := j.settings
+	for _, fn := range overrides {
+		fn(&settings)
+	}
+
+	// production mode cannot be changed per request (debug information is disabled by Validate)
+	settings.Production = j.settings.Production
+	settings.Validate()
+
+	return settings
+}
+
+// getLogger returns logger for errors, parent logger or zap global logger is used when not set
+func (j *jayson) getLogger() *zap.Logger {
+	if j.logger != nil {
+		return j.logger
+	}
+	if j.parent != nil {
+		return j.parent.getLogger()
+	}
+	return zap.L()
+}
+
 // debugLogMethod logs caller info
 func (j *jayson) debugLogMethod(method string, fn ...func() []zap.Field) {
 	if j.debug == nil {
@@ -26,6 +26,7 @@ package jayson_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/phonkee/jayson"
@@ -303,3 +304,267 @@ func TestJayson_Error(t *testing.T) {
 	})
 
 }
+
+func TestJayson_Production(t *testing.T) {
+	productionSettings := func() jayson.Settings {
+		s := testSettings()
+		s.Production = true
+		return s
+	}
+
+	t.Run("test unregistered error is hidden", func(t *testing.T) {
+		observedZapCore, observedLogs := observer.New(zap.ErrorLevel)
+
+		jay := jayson.New(productionSettings())
+		jay.Logger(zap.New(observedZapCore))
+
+		rw := httptest.NewRecorder()
+		jay.Error(context.Background(), rw, errors.New("dial tcp 10.0.0.1:5432: connection refused"))
+
+		assert.Equal(t, http.StatusInternalServerError, rw.Code)
+		assert.NotContains(t, rw.Body.String(), "10.0.0.1")
+
+		body := make(map[string]any)
+		assert.NoError(t, json.Unmarshal(rw.Body.Bytes(), &body))
+		assert.Equal(t, "Internal Server Error", body[ErrorMessageKey])
+		assert.NotEmpty(t, body["error_id"])
+
+		// real error is logged under the same id
+		if assert.Len(t, observedLogs.All(), 1) {
+			fields := observedLogs.All()[0].ContextMap()
+			assert.Equal(t, body["error_id"], fields["error_id"])
+			assert.Equal(t, "dial tcp 10.0.0.1:5432: connection refused", fields["error"])
+		}
+	})
+
+	t.Run("test registered client error is not hidden", func(t *testing.T) {
+		jay := jayson.New(productionSettings())
+		jay.Logger(zap.NewNop())
+		assert.NoError(t, jay.RegisterError(Error1, jayson.ExtStatus(http.StatusNotFound)))
+
+		assertErrorJSON(t, jay, Error2, `{"`+ErrorStatusCodeKey+`":404,"`+ErrorMessageKey+`":"error2: error1","`+ErrorStatusTextKey+`":"Not Found"}`, http.StatusNotFound, nil)
+	})
+
+	t.Run("test registered server error is hidden with custom message", func(t *testing.T) {
+		s := productionSettings()
+		s.ProductionErrorMessage = "something went wrong"
+		jay := jayson.New(s)
+		jay.Logger(zap.NewNop())
+		assert.NoError(t, jay.RegisterError(Error1, jayson.ExtStatus(http.StatusBadGateway)))
+
+		rw := httptest.NewRecorder()
+		jay.Error(context.Background(), rw, Error1)
+
+		body := make(map[string]any)
+		assert.NoError(t, json.Unmarshal(rw.Body.Bytes(), &body))
+		assert.Equal(t, "something went wrong", body[ErrorMessageKey])
+		assert.NotEmpty(t, body["error_id"])
+	})
+
+	t.Run("test error id is available to extensions", func(t *testing.T) {
+		jay := jayson.New(productionSettings())
+		jay.Logger(zap.NewNop())
+
+		var ctxID string
+		rw := httptest.NewRecorder()
+		jay.Error(context.Background(), rw, Error1, jayson.ExtFunc(
+			func(ctx context.Context, w http.ResponseWriter) bool {
+				ctxID, _ = jayson.ContextErrorIDValue(ctx)
+				w.Header().Set("X-Error-ID", ctxID)
+				return true
+			},
+			nil,
+		))
+
+		assert.NotEmpty(t, ctxID)
+		assert.Equal(t, ctxID, rw.Header().Get("X-Error-ID"))
+	})
+
+	t.Run("test debug mode does not hide errors", func(t *testing.T) {
+		jay := jayson.New(testSettings())
+		assertErrorJSON(t, jay, Error1, `{"`+ErrorStatusCodeKey+`":500,"`+ErrorMessageKey+`":"error1","`+ErrorStatusTextKey+`":"Internal Server Error"}`, http.StatusInternalServerError, nil)
+	})
+}
+
+// describedError describes itself via HTTPStatuser, ErrorCoder and ErrorFielder interfaces
+type describedError struct {
+	status int
+	code   string
+	fields map[string]any
+}
+
+func (d describedError) Error() string               { return "described" }
+func (d describedError) HTTPStatus() int             { return d.status }
+func (d describedError) ErrorCode() string           { return d.code }
+func (d describedError) ErrorFields() map[string]any { return d.fields }
+
+func TestJayson_Error_Interfaces(t *testing.T) {
+	t.Run("test error describes itself", func(t *testing.T) {
+		jay := jayson.New(testSettings())
+		err := describedError{status: http.StatusConflict, code: "user_exists", fields: map[string]any{"field": "email"}}
+
+		assertErrorJSON(t, jay, err, `{"`+ErrorStatusCodeKey+`":409,"`+ErrorMessageKey+`":"described","`+ErrorStatusTextKey+`":"Conflict","error_code":"user_exists","field":"email"}`, http.StatusConflict, nil)
+	})
+
+	t.Run("test interfaces are found in unwrap chain", func(t *testing.T) {
+		jay := jayson.New(testSettings())
+		err := fmt.Errorf("wrapped: %w", describedError{status: http.StatusConflict})
+
+		assertErrorJSON(t, jay, err, `{"`+ErrorStatusCodeKey+`":409,"`+ErrorMessageKey+`":"wrapped: described","`+ErrorStatusTextKey+`":"Conflict"}`, http.StatusConflict, nil)
+	})
+
+	t.Run("test uncomparable error cannot be registered", func(t *testing.T) {
+		jay := jayson.New(testSettings())
+		assert.ErrorIs(t, jay.RegisterError(describedError{}), jayson.ErrImproperlyConfigured)
+	})
+
+	t.Run("test zero values are ignored", func(t *testing.T) {
+		jay := jayson.New(testSettings())
+
+		assertErrorJSON(t, jay, describedError{}, `{"`+ErrorStatusCodeKey+`":500,"`+ErrorMessageKey+`":"described","`+ErrorStatusTextKey+`":"Internal Server Error"}`, http.StatusInternalServerError, nil)
+	})
+
+	t.Run("test registered extensions take precedence", func(t *testing.T) {
+		jay := jayson.New(testSettings())
+		err := describedError{status: http.StatusConflict, code: "user_exists"}
+		wrapped := fmt.Errorf("wrapped: %w", err)
+		assert.NoError(t, jay.RegisterError(wrapped, jayson.ExtStatus(http.StatusTeapot), jayson.ExtErrorCode("teapot")))
+
+		assertErrorJSON(t, jay, wrapped, `{"`+ErrorStatusCodeKey+`":418,"`+ErrorMessageKey+`":"wrapped: described","`+ErrorStatusTextKey+`":"I'm a teapot","error_code":"teapot"}`, http.StatusTeapot, nil)
+	})
+}
+
+func TestJayson_Render(t *testing.T) {
+	t.Run("test render error", func(t *testing.T) {
+		jay := jayson.New(testSettings())
+		assert.NoError(t, jay.RegisterError(Error1, jayson.ExtStatus(http.StatusNotFound), jayson.ExtHeaderValue("X-Hello", "World")))
+
+		rendered := jay.RenderError(context.Background(), Error2)
+		assert.Equal(t, http.StatusNotFound, rendered.Status)
+		assert.Equal(t, "World", rendered.Header.Get("X-Hello"))
+		assert.Equal(t, "application/json", rendered.Header.Get("Content-Type"))
+		assert.JSONEq(t, `{"`+ErrorStatusCodeKey+`":404,"`+ErrorMessageKey+`":"error2: error1","`+ErrorStatusTextKey+`":"Not Found"}`, string(rendered.Body))
+
+		// rendered error is the same as written error
+		rw := httptest.NewRecorder()
+		jay.Error(context.Background(), rw, Error2)
+		assert.Equal(t, rw.Body.Bytes(), rendered.Body)
+	})
+
+	t.Run("test render nil error", func(t *testing.T) {
+		jay := jayson.New(testSettings())
+		assert.Zero(t, jay.RenderError(context.Background(), nil))
+	})
+
+	t.Run("test render response", func(t *testing.T) {
+		jay := jayson.New(testSettings())
+		assert.NoError(t, jay.RegisterResponse(testResponse{}, jayson.ExtStatus(http.StatusCreated)))
+
+		rendered := jay.RenderResponse(context.Background(), testResponse{Answer: 42})
+		assert.Equal(t, http.StatusCreated, rendered.Status)
+		assert.Equal(t, "application/json", rendered.Header.Get("Content-Type"))
+		assert.JSONEq(t, `{"answer":42}`, string(rendered.Body))
+	})
+}
+
+func TestJayson_Child(t *testing.T) {
+	parent := jayson.New(testSettings())
+	assert.NoError(t, parent.RegisterError(Error1, jayson.ExtStatus(http.StatusNotFound)))
+
+	child := parent.Child(func(s *jayson.Settings) {
+		s.DefaultErrorMessageKey = "msg"
+	})
+
+	t.Run("test child inherits registrations", func(t *testing.T) {
+		assertErrorJSON(t, child, Error1, `{"`+ErrorStatusCodeKey+`":404,"msg":"error1","`+ErrorStatusTextKey+`":"Not Found"}`, http.StatusNotFound, nil)
+	})
+
+	t.Run("test parent changes are visible in child", func(t *testing.T) {
+		assert.NoError(t, parent.RegisterResponse(testResponse{}, jayson.ExtStatus(http.StatusCreated)))
+		assertResponseJSON(t, child, testResponse{Answer: 42}, `{"answer":42}`, http.StatusCreated, nil)
+	})
+
+	t.Run("test child registrations override parent", func(t *testing.T) {
+		assert.NoError(t, child.RegisterError(Error1, jayson.ExtStatus(http.StatusGone)))
+		assertErrorJSON(t, child, Error1, `{"`+ErrorStatusCodeKey+`":410,"msg":"error1","`+ErrorStatusTextKey+`":"Gone"}`, http.StatusGone, nil)
+		assertErrorJSON(t, parent, Error1, `{"`+ErrorStatusCodeKey+`":404,"`+ErrorMessageKey+`":"error1","`+ErrorStatusTextKey+`":"Not Found"}`, http.StatusNotFound, nil)
+	})
+
+	t.Run("test shared extensions are inherited", func(t *testing.T) {
+		assert.NoError(t, parent.RegisterError(jayson.Any, jayson.ExtHeaderValue("X-Parent", "1")))
+		assert.NoError(t, child.RegisterError(jayson.Any, jayson.ExtHeaderValue("X-Child", "1")))
+
+		rendered := child.RenderError(context.Background(), Error2)
+		assert.Equal(t, "1", rendered.Header.Get("X-Parent"))
+		assert.Equal(t, "1", rendered.Header.Get("X-Child"))
+		assert.Empty(t, parent.RenderError(context.Background(), Error2).Header.Get("X-Child"))
+	})
+}
+
+func TestJayson_ContextWithSettings(t *testing.T) {
+	jay := jayson.New(testSettings())
+	assert.NoError(t, jay.RegisterError(Error1, jayson.ExtErrorCode("error_1")))
+
+	ctx := jayson.ContextWithSettings(context.Background(), func(s *jayson.Settings) {
+		s.DefaultErrorMessageKey = "msg"
+		s.DefaultErrorCodeKey = "kind"
+		s.DefaultErrorStatus = http.StatusBadGateway
+	})
+
+	t.Run("test error honors overrides", func(t *testing.T) {
+		rendered := jay.RenderError(ctx, Error2)
+		assert.Equal(t, http.StatusBadGateway, rendered.Status)
+		assert.JSONEq(t, `{"`+ErrorStatusCodeKey+`":502,"msg":"error2: error1","`+ErrorStatusTextKey+`":"Bad Gateway","kind":"error_1"}`, string(rendered.Body))
+	})
+
+	t.Run("test instance settings are untouched", func(t *testing.T) {
+		assertErrorJSON(t, jay, Error2, `{"`+ErrorStatusCodeKey+`":500,"`+ErrorMessageKey+`":"error2: error1","`+ErrorStatusTextKey+`":"Internal Server Error","error_code":"error_1"}`, http.StatusInternalServerError, nil)
+	})
+
+	t.Run("test response honors overrides", func(t *testing.T) {
+		ctx := jayson.ContextWithSettings(context.Background(), func(s *jayson.Settings) {
+			s.DefaultResponseStatus = http.StatusAccepted
+			s.DefaultUnwrapObjectKey = "value"
+		})
+		rendered := jay.RenderResponse(ctx, jayson.ExtObjectUnwrap(42))
+		assert.Equal(t, http.StatusAccepted, rendered.Status)
+		assert.JSONEq(t, `{"value":42}`, string(rendered.Body))
+	})
+}
+
+func TestJayson_Error_KeyPaths(t *testing.T) {
+	t.Run("test nested keys", func(t *testing.T) {
+		jay := jayson.New(jayson.Settings{
+			DefaultErrorMessageKey:    "error.message",
+			DefaultErrorStatusCodeKey: "error.status.code",
+			DefaultErrorStatusTextKey: "error.status.text",
+			DefaultErrorCodeKey:       "error.code",
+		})
+		assert.NoError(t, jay.RegisterError(Error1, jayson.ExtStatus(http.StatusNotFound), jayson.ExtErrorCode("e1")))
+
+		assertErrorJSON(t, jay, Error1, `{"error":{"message":"error1","code":"e1","status":{"code":404,"text":"Not Found"}}}`, http.StatusNotFound, nil)
+	})
+
+	t.Run("test disabled keys", func(t *testing.T) {
+		s := testSettings()
+		s.DefaultErrorStatusTextKey = jayson.KeyDisabled
+		s.DefaultErrorCodeKey = jayson.KeyDisabled
+		jay := jayson.New(s)
+		assert.NoError(t, jay.RegisterError(Error1, jayson.ExtErrorCode("e1")))
+
+		assertErrorJSON(t, jay, Error1, `{"`+ErrorStatusCodeKey+`":500,"`+ErrorMessageKey+`":"error1"}`, http.StatusInternalServerError, nil)
+	})
+
+	t.Run("test omit nested settings key", func(t *testing.T) {
+		jay := jayson.New(jayson.Settings{
+			DefaultErrorMessageKey:    "error.message",
+			DefaultErrorStatusCodeKey: "error.code",
+			DefaultErrorStatusTextKey: "error.status",
+		})
+		assert.NoError(t, jay.RegisterError(Error1, jayson.ExtOmitSettingsKey(func(s jayson.Settings) []string {
+			return []string{s.DefaultErrorStatusTextKey, s.DefaultErrorStatusCodeKey}
+		})))
+
+		assertErrorJSON(t, jay, Error1, `{"error":{"message":"error1"}}`, http.StatusInternalServerError, nil)
+	})
+}
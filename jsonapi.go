@@ -0,0 +1,328 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Peter Vrba
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package jayson
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// jsonAPIContentType is media type of JSON:API documents
+	jsonAPIContentType = "application/vnd.api+json"
+	// jsonAPIAttributesPointer is prefix of JSON pointers to attributes of primary data
+	jsonAPIAttributesPointer = "/data/attributes/"
+)
+
+// ExtJSONAPI is an extension that enables/disables JSON:API document mode of response or error.
+func ExtJSONAPI(enabled bool) Extension {
+	return extResponseWriter(func(rw *responseWriter) {
+		rw.jsonAPI = enabled
+	})
+}
+
+// contentType returns content type of rendered body
+func (r *responseWriter) contentType() string {
+	if r.jsonAPI {
+		return jsonAPIContentType
+	}
+	return "application/json"
+}
+
+// jsonAPIDocument returns JSON:API document with given value as primary data.
+// Structs with id field (`jayson:"id,type=users"`) are rendered as resource objects.
+func (r *responseWriter) jsonAPIDocument(what any) *object {
+	doc := newObject(1)
+	doc.Set("data", r.jsonAPIData(reflect.ValueOf(what)))
+
+	// member names of document are fixed, so key naming and sparse fieldset were applied to attributes only
+	r.options.fields, r.options.keyNaming = nil, nil
+
+	return doc
+}
+
+// jsonAPIUnwrapDocument returns JSON:API document for object built by extensions, when unwrapped object
+// is resource, keys added by extensions are rendered in document meta.
+func (r *responseWriter) jsonAPIUnwrapDocument(what any, obj map[string]any) *object {
+	unwrapper, ok := what.(objectUnwrapper)
+	if !ok {
+		return r.jsonAPIDocument(obj)
+	}
+
+	val := reflect.ValueOf(unwrapper.unwrappedObject())
+	for val.Kind() == reflect.Pointer && !val.IsNil() {
+		val = val.Elem()
+	}
+	if val.Kind() != reflect.Struct || jsonAPIIDField(val.Type()) == nil {
+		return r.jsonAPIDocument(obj)
+	}
+
+	// keys that were not provided by the resource itself
+	own := make(map[string]any)
+	structToMap(val, own, r.options.views)
+	meta := make(map[string]any)
+	for key, value := range obj {
+		if _, ok := own[key]; !ok {
+			meta[key] = value
+		}
+	}
+
+	doc := r.jsonAPIDocument(val.Interface())
+	if len(meta) > 0 {
+		doc.Set("meta", meta)
+	}
+	return doc
+}
+
+// jsonAPIData returns primary data of JSON:API document
+func (r *responseWriter) jsonAPIData(v reflect.Value) any {
+	for v.IsValid() && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		if !isMarshalerType(v.Type()) {
+			return r.jsonAPIResource(v)
+		}
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() != reflect.Uint8 {
+			result := make([]any, v.Len())
+			for i := range result {
+				result[i] = r.jsonAPIData(v.Index(i))
+			}
+			return result
+		}
+	default:
+		// no-op
+	}
+
+	return r.jsonAPIAttributes(toTree(v, r.options.treeOptions(), 0))
+}
+
+// jsonAPIResource returns JSON:API resource object of struct (structs without id field are rendered as they are)
+func (r *responseWriter) jsonAPIResource(v reflect.Value) any {
+	tree := toTree(v, r.options.treeOptions(), 0)
+
+	idField := jsonAPIIDField(v.Type())
+	attributes, ok := tree.(*object)
+	if idField == nil || !ok {
+		return r.jsonAPIAttributes(tree)
+	}
+
+	result := newObject(4)
+	result.Set("type", jsonAPIType(v.Type(), idField))
+	result.Set("id", jsonAPIID(v, idField))
+
+	relationships := newObject(0)
+	for _, field := range cachedStructInfo(v.Type()).fields {
+		switch {
+		case field.jayson.id:
+			attributes.Delete(field.name)
+		case field.jayson.relationship:
+			attributes.Delete(field.name)
+			if !field.visible(r.options.views) {
+				continue
+			}
+			fv, _ := fieldByIndex(v, field.index)
+			data := newObject(1)
+			data.Set("data", jsonAPIIdentifiers(fv))
+			relationships.Set(r.options.renderedKey(field.name), data)
+		default:
+			// attributes
+		}
+	}
+
+	if attributes := r.jsonAPIAttributes(attributes).(*object); attributes.Len() > 0 {
+		result.Set("attributes", attributes)
+	}
+	if relationships.Len() > 0 {
+		result.Set("relationships", relationships)
+	}
+
+	return result
+}
+
+// jsonAPIAttributes applies key naming and sparse fieldset to attributes
+func (r *responseWriter) jsonAPIAttributes(tree any) any {
+	if r.options.keyNaming != nil {
+		tree = renameKeys(tree, r.options.keyNaming)
+	}
+	if len(r.options.fields) > 0 {
+		tree = newFieldTree(r.options.fields).project(tree)
+	}
+	return tree
+}
+
+// jsonAPIIdentifiers returns resource identifier (or identifiers) of relationship value
+func jsonAPIIdentifiers(v reflect.Value) any {
+	for v.IsValid() && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		idField := jsonAPIIDField(v.Type())
+		if idField == nil {
+			return nil
+		}
+		result := newObject(2)
+		result.Set("type", jsonAPIType(v.Type(), idField))
+		result.Set("id", jsonAPIID(v, idField))
+		return result
+	case reflect.Slice, reflect.Array:
+		result := make([]any, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			if identifier := jsonAPIIdentifiers(v.Index(i)); identifier != nil {
+				result = append(result, identifier)
+			}
+		}
+		return result
+	default:
+		return nil
+	}
+}
+
+// jsonAPIIDField returns id field of struct type (nil when struct is not resource)
+func jsonAPIIDField(typ reflect.Type) *structField {
+	for _, field := range cachedStructInfo(typ).fields {
+		if field.jayson.id {
+			return &field
+		}
+	}
+	return nil
+}
+
+// jsonAPIType returns resource type (lowercase type name is used when type is not given in tag)
+func jsonAPIType(typ reflect.Type, idField *structField) string {
+	if idField.jayson.resourceType != "" {
+		return idField.jayson.resourceType
+	}
+	return strings.ToLower(typ.Name())
+}
+
+// jsonAPIID returns resource id as string
+func jsonAPIID(v reflect.Value, idField *structField) string {
+	fv, ok := fieldByIndex(v, idField.index)
+	if !ok {
+		return ""
+	}
+	for fv.Kind() == reflect.Pointer || fv.Kind() == reflect.Interface {
+		if fv.IsNil() {
+			return ""
+		}
+		fv = fv.Elem()
+	}
+	return fmt.Sprint(fv.Interface())
+}
+
+// jsonAPIErrors returns JSON:API errors document built from error object (as rendered by registered extensions).
+// Invalid attributes of FieldErrorer errors are rendered as separate errors with source pointer to attributes,
+// fields of ErrorFielder errors are kept in meta as other keys.
+func (r *responseWriter) jsonAPIErrors(err error, obj map[string]any, s Settings) *object {
+	base := newObject(5)
+
+	// error id is available only in production mode
+	if id, ok := getKeyPath(obj, s.DefaultErrorIDKey); ok {
+		base.Set("id", id)
+	}
+	base.Set("status", strconv.Itoa(r.statusCode))
+	if code, ok := getKeyPath(obj, s.DefaultErrorCodeKey); ok {
+		base.Set("code", code)
+	}
+	base.Set("title", http.StatusText(r.statusCode))
+	if detail, ok := getKeyPath(obj, s.DefaultErrorDetailKey); ok {
+		base.Set("detail", detail)
+	} else if message, ok := getKeyPath(obj, s.DefaultErrorMessageKey); ok {
+		base.Set("detail", message)
+	}
+
+	// everything else is rendered in meta
+	for _, key := range []string{
+		s.DefaultErrorIDKey, s.DefaultErrorCodeKey, s.DefaultErrorDetailKey, s.DefaultErrorMessageKey,
+		s.DefaultErrorStatusCodeKey, s.DefaultErrorStatusTextKey,
+	} {
+		deleteKeyPath(obj, key)
+	}
+
+	var fields map[string]string
+	if fielder := FieldErrorer(nil); errors.As(err, &fielder) {
+		fields = fielder.FieldErrors()
+	}
+	if len(obj) > 0 {
+		base.Set("meta", obj)
+	}
+
+	result := make([]any, 0, max(len(fields), 1))
+	if len(fields) == 0 {
+		result = append(result, base)
+	}
+
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		entry := newObject(base.Len() + 1)
+		for _, k := range base.Keys() {
+			value, _ := base.Get(k)
+			entry.Set(k, value)
+		}
+		if detail := fields[key]; detail != "" {
+			entry.Set("detail", detail)
+		}
+		source := newObject(1)
+		source.Set("pointer", jsonAPIAttributesPointer+r.options.renderedKey(key))
+		entry.Set("source", source)
+		result = append(result, entry)
+	}
+
+	doc := newObject(1)
+	doc.Set("errors", result)
+
+	// member names of document are fixed
+	r.options.keyNaming = nil
+
+	return doc
+}
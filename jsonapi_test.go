@@ -0,0 +1,222 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Peter Vrba
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package jayson
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type jsonAPIAuthor struct {
+	ID   string `json:"id" jayson:"id,type=people"`
+	Name string `json:"name"`
+}
+
+type jsonAPIArticle struct {
+	ID       int             `json:"id" jayson:"id,type=articles"`
+	Title    string          `json:"title"`
+	BodyText string          `json:"body_text"`
+	Secret   string          `json:"secret" jayson:"view=admin"`
+	Author   *jsonAPIAuthor  `json:"author" jayson:"relationship"`
+	Comments []jsonAPIAuthor `json:"commenters" jayson:"relationship"`
+}
+
+type jsonAPIPlain struct {
+	Value int `json:"value"`
+}
+
+type jsonAPITyped struct {
+	Key string `json:"key" jayson:"id"`
+}
+
+func TestJSONAPI(t *testing.T) {
+	article := jsonAPIArticle{
+		ID:       1,
+		Title:    "JSON:API",
+		BodyText: "body",
+		Secret:   "secret",
+		Author:   &jsonAPIAuthor{ID: "9", Name: "Peter"},
+		Comments: []jsonAPIAuthor{{ID: "2"}, {ID: "3"}},
+	}
+
+	settings := func() Settings {
+		s := DefaultSettings()
+		s.JSONAPI = true
+		return s
+	}
+
+	t.Run("test resource", func(t *testing.T) {
+		rendered := New(settings()).RenderResponse(context.Background(), article)
+		assert.Equal(t, "application/vnd.api+json", rendered.Header.Get("Content-Type"))
+		assert.JSONEq(t, `{"data":{
+			"type":"articles","id":"1",
+			"attributes":{"title":"JSON:API","body_text":"body"},
+			"relationships":{
+				"author":{"data":{"type":"people","id":"9"}},
+				"commenters":{"data":[{"type":"people","id":"2"},{"type":"people","id":"3"}]}
+			}
+		}}`, string(rendered.Body))
+	})
+
+	t.Run("test collection", func(t *testing.T) {
+		rendered := New(settings()).RenderResponse(context.Background(), []*jsonAPIAuthor{{ID: "1", Name: "a"}, {ID: "2", Name: "b"}})
+		assert.JSONEq(t, `{"data":[
+			{"type":"people","id":"1","attributes":{"name":"a"}},
+			{"type":"people","id":"2","attributes":{"name":"b"}}
+		]}`, string(rendered.Body))
+
+		rendered = New(settings()).RenderResponse(context.Background(), []jsonAPIAuthor{})
+		assert.JSONEq(t, `{"data":[]}`, string(rendered.Body))
+	})
+
+	t.Run("test default type and nil relationship", func(t *testing.T) {
+		rendered := New(settings()).RenderResponse(context.Background(), jsonAPITyped{Key: "k"})
+		assert.JSONEq(t, `{"data":{"type":"jsonapityped","id":"k"}}`, string(rendered.Body))
+
+		rendered = New(settings()).RenderResponse(context.Background(), jsonAPIArticle{ID: 2})
+		assert.JSONEq(t, `{"data":{"type":"articles","id":"2","attributes":{"title":"","body_text":""},"relationships":{"author":{"data":null},"commenters":{"data":[]}}}}`, string(rendered.Body))
+	})
+
+	t.Run("test non resource", func(t *testing.T) {
+		rendered := New(settings()).RenderResponse(context.Background(), jsonAPIPlain{Value: 1})
+		assert.JSONEq(t, `{"data":{"value":1}}`, string(rendered.Body))
+
+		rendered = New(settings()).RenderResponse(context.Background(), []int{1, 2})
+		assert.JSONEq(t, `{"data":[1,2]}`, string(rendered.Body))
+	})
+
+	t.Run("test views, naming and fields", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/?fields=bodyText,secret", nil)
+		ctx := ContextWithView(ContextWithRequest(r.Context(), r), "admin")
+		rendered := New(settings()).RenderResponse(ctx, article, ExtKeyNaming(KeyNamingCamel), ExtFieldsQuery("fields"))
+		assert.JSONEq(t, `{"data":{
+			"type":"articles","id":"1",
+			"attributes":{"bodyText":"body","secret":"secret"},
+			"relationships":{
+				"author":{"data":{"type":"people","id":"9"}},
+				"commenters":{"data":[{"type":"people","id":"2"},{"type":"people","id":"3"}]}
+			}
+		}}`, string(rendered.Body))
+	})
+
+	t.Run("test unknown field", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/?fields=nope", nil)
+		ctx := ContextWithRequest(r.Context(), r)
+
+		rendered := New(settings()).RenderResponse(ctx, article, ExtFieldsQuery("fields"))
+		assert.Equal(t, http.StatusBadRequest, rendered.Status)
+		assert.NotContains(t, string(rendered.Body), `"data"`)
+
+		rendered = New(settings()).RenderResponse(ctx, ExtObjectUnwrap(article), ExtFieldsQuery("fields"))
+		assert.Equal(t, http.StatusBadRequest, rendered.Status)
+		assert.NotContains(t, string(rendered.Body), `"data"`)
+	})
+
+	t.Run("test object unwrap", func(t *testing.T) {
+		rendered := New(settings()).RenderResponse(context.Background(),
+			ExtObjectUnwrap(jsonAPIAuthor{ID: "1", Name: "a"}),
+			ExtObjectKeyValue("total", 1),
+		)
+		assert.JSONEq(t, `{"data":{"type":"people","id":"1","attributes":{"name":"a"}},"meta":{"total":1}}`, string(rendered.Body))
+
+		rendered = New(settings()).RenderResponse(context.Background(), ExtObjectKeyValue("total", 1))
+		assert.JSONEq(t, `{"data":{"total":1}}`, string(rendered.Body))
+	})
+
+	t.Run("test extension", func(t *testing.T) {
+		rendered := New(DefaultSettings()).RenderResponse(context.Background(), jsonAPITyped{Key: "k"}, ExtJSONAPI(true))
+		assert.JSONEq(t, `{"data":{"type":"jsonapityped","id":"k"}}`, string(rendered.Body))
+
+		rendered = New(settings()).RenderResponse(context.Background(), jsonAPITyped{Key: "k"}, ExtJSONAPI(false))
+		assert.Equal(t, "application/json", rendered.Header.Get("Content-Type"))
+		assert.JSONEq(t, `{"key":"k"}`, string(rendered.Body))
+	})
+}
+
+// jsonAPIValidationError is validation error with invalid attributes
+type jsonAPIValidationError struct {
+	fields map[string]string
+}
+
+func (e *jsonAPIValidationError) Error() string { return "validation failed" }
+
+func (e *jsonAPIValidationError) FieldErrors() map[string]string { return e.fields }
+
+func TestJSONAPIErrors(t *testing.T) {
+	errNotFound := errors.New("article not found")
+
+	newJayson := func() Jayson {
+		s := DefaultSettings()
+		s.JSONAPI = true
+		j := New(s)
+		Must(j.RegisterError(errNotFound, ExtStatus(http.StatusNotFound), ExtErrorCode("not_found")))
+		return j
+	}
+
+	t.Run("test registered error", func(t *testing.T) {
+		rendered := newJayson().RenderError(context.Background(), errNotFound)
+		assert.Equal(t, http.StatusNotFound, rendered.Status)
+		assert.Equal(t, "application/vnd.api+json", rendered.Header.Get("Content-Type"))
+		assert.JSONEq(t, `{"errors":[{"status":"404","code":"not_found","title":"Not Found","detail":"article not found"}]}`, string(rendered.Body))
+	})
+
+	t.Run("test fields", func(t *testing.T) {
+		err := NewError("not_found").Status(http.StatusNotFound).Field("id", 42)
+		rendered := newJayson().RenderError(context.Background(), err)
+		assert.JSONEq(t, `{"errors":[
+			{"status":"404","code":"not_found","title":"Not Found","detail":"not_found","meta":{"id":42}}
+		]}`, string(rendered.Body))
+	})
+
+	t.Run("test field errors", func(t *testing.T) {
+		err := &jsonAPIValidationError{fields: map[string]string{"title": "must not be empty", "body_text": "too short"}}
+		rendered := newJayson().RenderError(context.Background(), err,
+			ExtStatus(http.StatusUnprocessableEntity), ExtErrorCode("invalid"), ExtKeyNaming(KeyNamingCamel), ExtObjectKeyValue("trace", "abc"))
+		assert.JSONEq(t, `{"errors":[
+			{"status":"422","code":"invalid","title":"Unprocessable Entity","detail":"too short","meta":{"trace":"abc"},"source":{"pointer":"/data/attributes/bodyText"}},
+			{"status":"422","code":"invalid","title":"Unprocessable Entity","detail":"must not be empty","meta":{"trace":"abc"},"source":{"pointer":"/data/attributes/title"}}
+		]}`, string(rendered.Body))
+	})
+
+	t.Run("test production", func(t *testing.T) {
+		s := DefaultSettings()
+		s.JSONAPI = true
+		s.Production = true
+		rendered := New(s).RenderError(context.Background(), errors.New("db: connection refused"))
+		assert.Equal(t, http.StatusInternalServerError, rendered.Status)
+		assert.NotContains(t, string(rendered.Body), "connection refused")
+		assert.Contains(t, string(rendered.Body), `"id":"`)
+	})
+
+	t.Run("test extension", func(t *testing.T) {
+		rendered := New(DefaultSettings()).RenderError(context.Background(), ErrPreconditionFailed, ExtJSONAPI(true))
+		assert.JSONEq(t, `{"errors":[{"status":"412","title":"Precondition Failed","detail":"precondition failed"}]}`, string(rendered.Body))
+	})
+}
@@ -0,0 +1,100 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Peter Vrba
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package jayson
+
+import "strings"
+
+const (
+	// KeyDisabled disables settings key, value for disabled key is not rendered at all.
+	KeyDisabled = "-"
+	// keyPathSeparator separates levels of nested key path (e.g. "error.message")
+	keyPathSeparator = "."
+)
+
+// setKeyPath sets value under given key path, nested objects are created as needed.
+// Empty and disabled keys are ignored, it returns whether the value was set.
+func setKeyPath(m map[string]any, path string, value any) bool {
+	if path == "" || path == KeyDisabled {
+		return false
+	}
+
+	parts := strings.Split(path, keyPathSeparator)
+	for _, part := range parts[:len(parts)-1] {
+		nested, ok := m[part].(map[string]any)
+		if !ok {
+			nested = make(map[string]any)
+			m[part] = nested
+		}
+		m = nested
+	}
+
+	m[parts[len(parts)-1]] = value
+	return true
+}
+
+// deleteKeyPath deletes value under given key path, nested objects that become empty are removed too.
+// It returns whether the value was deleted.
+func deleteKeyPath(m map[string]any, path string) bool {
+	if path == "" || path == KeyDisabled {
+		return false
+	}
+
+	key, rest, nested := strings.Cut(path, keyPathSeparator)
+	if !nested {
+		if _, ok := m[key]; !ok {
+			return false
+		}
+		delete(m, key)
+		return true
+	}
+
+	child, ok := m[key].(map[string]any)
+	if !ok || !deleteKeyPath(child, rest) {
+		return false
+	}
+	if len(child) == 0 {
+		delete(m, key)
+	}
+	return true
+}
+
+// getKeyPath returns value under given key path.
+func getKeyPath(m map[string]any, path string) (any, bool) {
+	if path == "" || path == KeyDisabled {
+		return nil, false
+	}
+
+	key, rest, nested := strings.Cut(path, keyPathSeparator)
+	if !nested {
+		value, ok := m[key]
+		return value, ok
+	}
+
+	child, ok := m[key].(map[string]any)
+	if !ok {
+		return nil, false
+	}
+	return getKeyPath(child, rest)
+}
@@ -0,0 +1,87 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Peter Vrba
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package jayson
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSetKeyPath(t *testing.T) {
+	m := map[string]any{"error": "not an object"}
+
+	assert.True(t, setKeyPath(m, "flat", 1))
+	assert.True(t, setKeyPath(m, "error.message", "boom"))
+	assert.True(t, setKeyPath(m, "error.status.code", 500))
+	assert.False(t, setKeyPath(m, KeyDisabled, 1))
+	assert.False(t, setKeyPath(m, "", 1))
+
+	assert.Equal(t, map[string]any{
+		"flat": 1,
+		"error": map[string]any{
+			"message": "boom",
+			"status":  map[string]any{"code": 500},
+		},
+	}, m)
+}
+
+func TestDeleteKeyPath(t *testing.T) {
+	m := map[string]any{
+		"flat": 1,
+		"error": map[string]any{
+			"message": "boom",
+			"status":  map[string]any{"code": 500},
+		},
+	}
+
+	assert.False(t, deleteKeyPath(m, "missing"))
+	assert.False(t, deleteKeyPath(m, "flat.nested"))
+	assert.False(t, deleteKeyPath(m, KeyDisabled))
+	assert.True(t, deleteKeyPath(m, "flat"))
+	assert.True(t, deleteKeyPath(m, "error.status.code"))
+	assert.Equal(t, map[string]any{"error": map[string]any{"message": "boom"}}, m)
+	assert.True(t, deleteKeyPath(m, "error.message"))
+	assert.Empty(t, m)
+}
+
+func TestGetKeyPath(t *testing.T) {
+	m := map[string]any{
+		"flat":  1,
+		"error": map[string]any{"message": "boom"},
+	}
+
+	value, ok := getKeyPath(m, "flat")
+	assert.True(t, ok)
+	assert.Equal(t, 1, value)
+
+	value, ok = getKeyPath(m, "error.message")
+	assert.True(t, ok)
+	assert.Equal(t, "boom", value)
+
+	for _, path := range []string{"", KeyDisabled, "missing", "flat.nested", "error.missing"} {
+		_, ok = getKeyPath(m, path)
+		assert.False(t, ok, path)
+	}
+}
@@ -0,0 +1,132 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Peter Vrba
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package jayson
+
+import (
+	"reflect"
+	"strings"
+)
+
+// matchCacheKey is key of cached match (registry and response type)
+type matchCacheKey struct {
+	registry *registry[reflect.Type]
+	typ      reflect.Type
+}
+
+// matchCacheEntry is cached match valid for given registry generation
+type matchCacheEntry struct {
+	generation uint64
+	typ        reflect.Type
+	ok         bool
+}
+
+// responseType returns registry type of given response object.
+// Interface types are registered via nil pointer to interface (e.g. (*Auditable)(nil)).
+func responseType(what any) reflect.Type {
+	typ := reflect.TypeOf(what)
+	if typ != nil && typ.Kind() == reflect.Pointer && typ.Elem().Kind() == reflect.Interface {
+		return typ.Elem()
+	}
+	return typ
+}
+
+// matchResponseType returns extensions of registered interface implemented by given type
+// or of registered generic family of given type, matches are cached until next registration
+func (j *jayson) matchResponseType(
+	reg *registry[reflect.Type],
+	get func(reflect.Type) ([]Extension, bool),
+	what reflect.Type,
+) ([]Extension, bool) {
+	key := matchCacheKey{registry: reg, typ: what}
+	generation := reg.Generation()
+
+	entry, ok := j.matchCache.Load(key)
+	if !ok || entry.(matchCacheEntry).generation != generation {
+		typ, found := matchType(reg.Keys(), what)
+		entry = matchCacheEntry{generation: generation, typ: typ, ok: found}
+		j.matchCache.Store(key, entry)
+	}
+
+	if cached := entry.(matchCacheEntry); cached.ok {
+		return get(cached.typ)
+	}
+	return nil, false
+}
+
+// matchType returns first registered interface implemented by given type (or pointer to it),
+// then first registered generic family root of given type (generic type instantiated with any, e.g. Page[any])
+func matchType(registered []reflect.Type, what reflect.Type) (reflect.Type, bool) {
+	base := what
+	for base.Kind() == reflect.Pointer {
+		base = base.Elem()
+	}
+	ptr := reflect.PointerTo(base)
+
+	for _, typ := range registered {
+		if typ.Kind() == reflect.Interface && (what.Implements(typ) || base.Implements(typ) || ptr.Implements(typ)) {
+			return typ, true
+		}
+	}
+
+	name, ok := genericName(base)
+	if !ok {
+		return nil, false
+	}
+	for _, typ := range registered {
+		root := typ
+		if root.Kind() == reflect.Pointer {
+			root = root.Elem()
+		}
+		if root.PkgPath() != base.PkgPath() || !isFamilyRoot(root) {
+			continue
+		}
+		if rootName, _ := genericName(root); rootName == name {
+			return typ, true
+		}
+	}
+
+	return nil, false
+}
+
+// genericName returns name of generic type without type arguments
+func genericName(typ reflect.Type) (string, bool) {
+	name, _, ok := strings.Cut(typ.Name(), "[")
+	return name, ok
+}
+
+// isFamilyRoot returns whether generic type is instantiated with any as all type arguments
+func isFamilyRoot(typ reflect.Type) bool {
+	name := typ.Name()
+	start := strings.Index(name, "[")
+	if start == -1 || !strings.HasSuffix(name, "]") {
+		return false
+	}
+	for _, arg := range strings.Split(name[start+1:len(name)-1], ",") {
+		if arg != "interface {}" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,143 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Peter Vrba
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package jayson
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+type auditable interface {
+	AuditID() string
+}
+
+type auditableNamed interface {
+	auditable
+	Name() string
+}
+
+type matchOrder struct {
+	ID string `json:"id"`
+}
+
+func (m *matchOrder) AuditID() string { return m.ID }
+
+func (m *matchOrder) Name() string { return "order" }
+
+type matchPage[T any] struct {
+	Items []T `json:"items"`
+}
+
+type matchPair[K comparable, V any] struct{}
+
+func TestResponseType(t *testing.T) {
+	assert.Equal(t, reflect.TypeFor[auditable](), responseType((*auditable)(nil)))
+	assert.Equal(t, reflect.TypeFor[*matchOrder](), responseType(&matchOrder{}))
+	assert.Nil(t, responseType(nil))
+}
+
+func TestMatchType(t *testing.T) {
+	registered := []reflect.Type{
+		reflect.TypeFor[auditable](),
+		reflect.TypeFor[auditableNamed](),
+		reflect.TypeFor[matchPage[any]](),
+		reflect.TypeFor[*matchPair[string, any]](),
+	}
+
+	for _, item := range []struct {
+		name   string
+		what   reflect.Type
+		expect reflect.Type
+	}{
+		{name: "test interface value", what: reflect.TypeFor[matchOrder](), expect: reflect.TypeFor[auditable]()},
+		{name: "test interface pointer", what: reflect.TypeFor[*matchOrder](), expect: reflect.TypeFor[auditable]()},
+		{name: "test family", what: reflect.TypeFor[matchPage[matchOrder]](), expect: reflect.TypeFor[matchPage[any]]()},
+		{name: "test family pointer", what: reflect.TypeFor[*matchPage[int]](), expect: reflect.TypeFor[matchPage[any]]()},
+		{name: "test family partial root", what: reflect.TypeFor[matchPair[int, int]]()},
+		{name: "test no match", what: reflect.TypeFor[int]()},
+	} {
+		t.Run(item.name, func(t *testing.T) {
+			typ, ok := matchType(registered, item.what)
+			assert.Equal(t, item.expect != nil, ok)
+			assert.Equal(t, item.expect, typ)
+		})
+	}
+
+	assert.True(t, isFamilyRoot(reflect.TypeFor[matchPair[any, any]]()))
+	assert.False(t, isFamilyRoot(reflect.TypeFor[matchOrder]()))
+}
+
+func TestRegisterResponseInterface(t *testing.T) {
+	j := New(DefaultSettings())
+	assert.NoError(t, j.RegisterResponse((*auditable)(nil), ExtHeaderValue("X-Audit", "auditable")))
+	assert.NoError(t, j.RegisterResponse((*auditableNamed)(nil), ExtHeaderValue("X-Audit", "named")))
+	assert.NoError(t, j.RegisterResponse(matchPage[any]{}, ExtStatus(http.StatusPartialContent)))
+
+	for _, item := range []struct {
+		name   string
+		what   any
+		status int
+		header string
+	}{
+		{name: "test value", what: matchOrder{ID: "1"}, status: http.StatusOK, header: "auditable"},
+		{name: "test pointer", what: &matchOrder{ID: "1"}, status: http.StatusOK, header: "auditable"},
+		{name: "test slice", what: []*matchOrder{{ID: "1"}}, status: http.StatusOK, header: "auditable"},
+		{name: "test map", what: map[string]matchOrder{"a": {ID: "1"}}, status: http.StatusOK, header: "auditable"},
+		{name: "test array", what: [1]matchOrder{{ID: "1"}}, status: http.StatusOK, header: "auditable"},
+		{name: "test family", what: matchPage[matchOrder]{}, status: http.StatusPartialContent},
+		{name: "test unregistered", what: map[string]int{}, status: http.StatusOK},
+	} {
+		t.Run(item.name, func(t *testing.T) {
+			rw := httptest.NewRecorder()
+			j.Response(context.Background(), rw, item.what)
+			assert.Equal(t, item.status, rw.Code)
+			assert.Equal(t, item.header, rw.Header().Get("X-Audit"))
+		})
+	}
+
+	// exact registration takes precedence, cache is invalidated by registration (also in parent)
+	child := j.Child()
+	rw := httptest.NewRecorder()
+	child.Response(context.Background(), rw, matchOrder{})
+	assert.Equal(t, "auditable", rw.Header().Get("X-Audit"))
+
+	assert.NoError(t, j.RegisterResponse(matchOrder{}, ExtHeaderValue("X-Audit", "exact")))
+	rw = httptest.NewRecorder()
+	child.Response(context.Background(), rw, matchOrder{})
+	assert.Equal(t, "exact", rw.Header().Get("X-Audit"))
+
+	// child registrations take precedence
+	assert.NoError(t, child.RegisterResponse((*auditableNamed)(nil), ExtHeaderValue("X-Audit", "child")))
+	rw = httptest.NewRecorder()
+	child.Response(context.Background(), rw, &matchPage[int]{})
+	assert.Equal(t, http.StatusPartialContent, rw.Code)
+	rw = httptest.NewRecorder()
+	child.Response(context.Background(), rw, []auditableNamed{&matchOrder{}})
+	assert.Equal(t, "child", rw.Header().Get("X-Audit"))
+}
@@ -0,0 +1,249 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Peter Vrba
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package jayson
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// KeyNaming is strategy that rewrites keys of rendered objects (e.g. snake_case to camelCase).
+// Keys are rewritten in nested objects too, including keys added by extensions and keys of maps.
+type KeyNaming interface {
+	// Key returns rendered key for given key.
+	Key(string) string
+}
+
+// KeyNamingFunc is custom key naming strategy.
+type KeyNamingFunc func(string) string
+
+// Key calls the function.
+func (f KeyNamingFunc) Key(key string) string {
+	return f(key)
+}
+
+var (
+	// KeyNamingSnake renders keys in snake_case.
+	KeyNamingSnake KeyNaming = KeyNamingFunc(func(key string) string { return joinWords(key, "_", false) })
+	// KeyNamingCamel renders keys in camelCase.
+	KeyNamingCamel KeyNaming = KeyNamingFunc(func(key string) string { return joinWords(key, "", true) })
+	// KeyNamingKebab renders keys in kebab-case.
+	KeyNamingKebab KeyNaming = KeyNamingFunc(func(key string) string { return joinWords(key, "-", false) })
+)
+
+// ExtKeyNaming is an extension that sets key naming strategy of rendered object (nil disables renaming).
+func ExtKeyNaming(naming KeyNaming) Extension {
+	return &extKeyNaming{
+		Extension: extEncoderOptions(func(options *encoderOptions) {
+			options.keyNaming = naming
+		}),
+		naming: naming,
+	}
+}
+
+// extKeyNaming is key naming extension, Decode looks it up among registered extensions
+type extKeyNaming struct {
+	Extension
+	naming KeyNaming
+}
+
+// Decode decodes JSON from reader into v, keys rendered by key naming strategy (from settings or registered
+// response type of v) are matched back to struct fields of v.
+func (j *jayson) Decode(ctx context.Context, r io.Reader, v any) error {
+	// same error as json.Decoder returns
+	if rv := reflect.ValueOf(v); rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return &json.InvalidUnmarshalError{Type: reflect.TypeOf(v)}
+	}
+
+	naming := j.keyNaming(ctx, reflect.TypeOf(v))
+	if naming == nil {
+		return json.NewDecoder(r).Decode(v)
+	}
+
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+
+	var raw any
+	if err := decoder.Decode(&raw); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(unnameKeys(raw, reflect.TypeOf(v), naming))
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+// keyNaming returns key naming strategy for given type, settings are overridden by key naming extensions
+// registered for response type (or shared ones), other extensions are not executed
+func (j *jayson) keyNaming(ctx context.Context, typ reflect.Type) KeyNaming {
+	naming := j.contextSettings(ctx).KeyNaming
+
+	ext, _ := j.getResponseTypeExtensions(typ)
+	for _, e := range ext {
+		if typed, ok := e.(*extKeyNaming); ok {
+			naming = typed.naming
+		}
+	}
+
+	return naming
+}
+
+// renameKeys renames keys of all objects in tree
+func renameKeys(v any, naming KeyNaming) any {
+	switch value := v.(type) {
+	case *object:
+		result := newObject(value.Len())
+		for _, key := range value.Keys() {
+			item, _ := value.Get(key)
+			result.Set(naming.Key(key), renameKeys(item, naming))
+		}
+		return result
+	case []any:
+		result := make([]any, len(value))
+		for i, item := range value {
+			result[i] = renameKeys(item, naming)
+		}
+		return result
+	default:
+		return v
+	}
+}
+
+// unnameKeys renames keys of decoded JSON back to json names of struct fields of given type,
+// keys that do not match any field are kept as they are
+func unnameKeys(v any, typ reflect.Type, naming KeyNaming) any {
+	if typ == nil {
+		return v
+	}
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	// custom unmarshalers get their data untouched
+	if reflect.PointerTo(typ).Implements(unmarshalerType) {
+		return v
+	}
+
+	switch value := v.(type) {
+	case map[string]any:
+		result := make(map[string]any, len(value))
+		switch typ.Kind() {
+		case reflect.Struct:
+			fields := make(map[string]structField)
+			for _, field := range cachedStructInfo(typ).fields {
+				fields[naming.Key(field.name)] = field
+			}
+			for key, item := range value {
+				if field, ok := fields[key]; ok {
+					result[field.name] = unnameKeys(item, typ.FieldByIndex(field.index).Type, naming)
+				} else {
+					result[key] = item
+				}
+			}
+		case reflect.Map:
+			for key, item := range value {
+				result[key] = unnameKeys(item, typ.Elem(), naming)
+			}
+		default:
+			return v
+		}
+		return result
+	case []any:
+		if typ.Kind() != reflect.Slice && typ.Kind() != reflect.Array {
+			return v
+		}
+		result := make([]any, len(value))
+		for i, item := range value {
+			result[i] = unnameKeys(item, typ.Elem(), naming)
+		}
+		return result
+	default:
+		return v
+	}
+}
+
+// joinWords splits key into words and joins them with separator (leading separators are kept, e.g. "_links")
+func joinWords(key string, separator string, camel bool) string {
+	trimmed := strings.TrimLeft(key, "_-")
+	prefix := key[:len(key)-len(trimmed)]
+
+	var buf bytes.Buffer
+	buf.WriteString(prefix)
+	for i, word := range splitWords(trimmed) {
+		if i > 0 {
+			buf.WriteString(separator)
+		}
+		if camel && i > 0 {
+			runes := []rune(word)
+			runes[0] = unicode.ToUpper(runes[0])
+			word = string(runes)
+		}
+		buf.WriteString(word)
+	}
+
+	return buf.String()
+}
+
+// splitWords splits key into lowercase words by separators and case changes (e.g. "HTTPServer_id" => http, server, id)
+func splitWords(key string) []string {
+	var (
+		result []string
+		word   []rune
+	)
+
+	flush := func() {
+		if len(word) > 0 {
+			result = append(result, strings.ToLower(string(word)))
+			word = word[:0]
+		}
+	}
+
+	runes := []rune(key)
+	for i, r := range runes {
+		switch {
+		case r == '_' || r == '-' || unicode.IsSpace(r):
+			flush()
+			continue
+		case unicode.IsUpper(r) && i > 0:
+			prev := runes[i-1]
+			// lower => Upper ("userID") or end of acronym ("HTTPServer")
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) ||
+				(unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+				flush()
+			}
+		}
+		word = append(word, r)
+	}
+	flush()
+
+	return result
+}
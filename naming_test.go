@@ -0,0 +1,225 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Peter Vrba
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package jayson
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type namingAddress struct {
+	StreetName string `json:"street_name"`
+}
+
+type namingUser struct {
+	UserID    int             `json:"user_id"`
+	FirstName string          `json:"first_name"`
+	Address   namingAddress   `json:"home_address"`
+	Previous  []namingAddress `json:"previous_addresses"`
+	Labels    map[string]any  `json:"labels"`
+}
+
+func TestKeyNaming(t *testing.T) {
+	for _, item := range []struct {
+		key   string
+		snake string
+		camel string
+		kebab string
+	}{
+		{"", "", "", ""},
+		{"id", "id", "id", "id"},
+		{"user_id", "user_id", "userId", "user-id"},
+		{"userID", "user_id", "userId", "user-id"},
+		{"UserID", "user_id", "userId", "user-id"},
+		{"HTTPServer", "http_server", "httpServer", "http-server"},
+		{"first-name", "first_name", "firstName", "first-name"},
+		{"address2_line", "address2_line", "address2Line", "address2-line"},
+		{"_links", "_links", "_links", "_links"},
+		{"_embedded_items", "_embedded_items", "_embeddedItems", "_embedded-items"},
+	} {
+		assert.Equal(t, item.snake, KeyNamingSnake.Key(item.key), item.key)
+		assert.Equal(t, item.camel, KeyNamingCamel.Key(item.key), item.key)
+		assert.Equal(t, item.kebab, KeyNamingKebab.Key(item.key), item.key)
+	}
+
+	assert.Equal(t, "ID", KeyNamingFunc(strings.ToUpper).Key("id"))
+}
+
+func TestKeyNamingRender(t *testing.T) {
+	value := namingUser{
+		UserID:    1,
+		FirstName: "Peter",
+		Address:   namingAddress{StreetName: "Main"},
+		Previous:  []namingAddress{{StreetName: "Old"}},
+		Labels:    map[string]any{"is_admin": true},
+	}
+
+	camel := func() Settings {
+		s := DefaultSettings()
+		s.KeyNaming = KeyNamingCamel
+		return s
+	}
+
+	t.Run("test disabled by default", func(t *testing.T) {
+		rendered := New(DefaultSettings()).RenderResponse(context.Background(), value)
+		assert.Contains(t, string(rendered.Body), `"user_id"`)
+	})
+
+	t.Run("test settings", func(t *testing.T) {
+		rendered := New(camel()).RenderResponse(context.Background(), value)
+		assert.Equal(t,
+			`{"userId":1,"firstName":"Peter","homeAddress":{"streetName":"Main"},"previousAddresses":[{"streetName":"Old"}],"labels":{"isAdmin":true}}`+"\n",
+			string(rendered.Body),
+		)
+	})
+
+	t.Run("test extension keys", func(t *testing.T) {
+		rendered := New(camel()).RenderResponse(context.Background(), ExtChain(
+			ExtObjectUnwrap(namingAddress{StreetName: "Main"}),
+			ExtObjectKeyValue("request_id", "abc"),
+		))
+		assert.JSONEq(t, `{"streetName":"Main","requestId":"abc"}`, string(rendered.Body))
+	})
+
+	t.Run("test error", func(t *testing.T) {
+		s := camel()
+		s.Production = true
+		rendered := New(s).RenderError(context.Background(), NewError("invalid").Status(http.StatusBadRequest).Field("field_name", "x"))
+		assert.Contains(t, string(rendered.Body), `"errorCode":"invalid"`)
+		assert.Contains(t, string(rendered.Body), `"fieldName":"x"`)
+	})
+
+	t.Run("test response type", func(t *testing.T) {
+		j := New(DefaultSettings())
+		Must(j.RegisterResponse(namingAddress{}, ExtKeyNaming(KeyNamingKebab)))
+		assert.JSONEq(t, `{"street-name":"Main"}`, string(j.RenderResponse(context.Background(), namingAddress{StreetName: "Main"}).Body))
+		assert.Contains(t, string(j.RenderResponse(context.Background(), value).Body), `"street_name"`)
+
+		// extension can disable settings naming
+		assert.JSONEq(t, `{"street_name":"Main"}`, string(New(camel()).RenderResponse(context.Background(), namingAddress{StreetName: "Main"}, ExtKeyNaming(nil)).Body))
+	})
+
+	t.Run("test sparse fieldset", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/?fields=userId,homeAddress.streetName", nil)
+		rendered := New(camel()).RenderResponse(ContextWithRequest(r.Context(), r), value, ExtFieldsQuery("fields"))
+		assert.Equal(t, http.StatusOK, rendered.Status)
+		assert.JSONEq(t, `{"userId":1,"homeAddress":{"streetName":"Main"}}`, string(rendered.Body))
+
+		r = httptest.NewRequest(http.MethodGet, "/?fields=user_id", nil)
+		rendered = New(camel()).RenderResponse(ContextWithRequest(r.Context(), r), value, ExtFieldsQuery("fields"))
+		assert.Equal(t, http.StatusBadRequest, rendered.Status)
+	})
+}
+
+func TestDecode(t *testing.T) {
+	t.Run("test without naming", func(t *testing.T) {
+		var user namingUser
+		require.NoError(t, New(DefaultSettings()).Decode(context.Background(), strings.NewReader(`{"user_id":1}`), &user))
+		assert.Equal(t, 1, user.UserID)
+	})
+
+	t.Run("test round trip", func(t *testing.T) {
+		s := DefaultSettings()
+		s.KeyNaming = KeyNamingCamel
+		j := New(s)
+
+		value := namingUser{
+			UserID:    1,
+			FirstName: "Peter",
+			Address:   namingAddress{StreetName: "Main"},
+			Previous:  []namingAddress{{StreetName: "Old"}},
+			Labels:    map[string]any{"color": "red"},
+		}
+		rendered := j.RenderResponse(context.Background(), value)
+
+		var decoded namingUser
+		require.NoError(t, j.Decode(context.Background(), strings.NewReader(string(rendered.Body)), &decoded))
+		assert.Equal(t, value, decoded)
+	})
+
+	t.Run("test response type naming", func(t *testing.T) {
+		j := New(DefaultSettings())
+		Must(j.RegisterResponse(namingAddress{}, ExtKeyNaming(KeyNamingKebab)))
+
+		var address namingAddress
+		require.NoError(t, j.Decode(context.Background(), strings.NewReader(`{"street-name":"Main"}`), &address))
+		assert.Equal(t, "Main", address.StreetName)
+	})
+
+	t.Run("test context settings", func(t *testing.T) {
+		ctx := ContextWithSettings(context.Background(), func(s *Settings) {
+			s.KeyNaming = KeyNamingKebab
+		})
+		var user namingUser
+		require.NoError(t, New(DefaultSettings()).Decode(ctx, strings.NewReader(`{"user-id":1,"home-address":{"street-name":"Main"}}`), &user))
+		assert.Equal(t, 1, user.UserID)
+		assert.Equal(t, "Main", user.Address.StreetName)
+	})
+
+	t.Run("test invalid", func(t *testing.T) {
+		s := DefaultSettings()
+		s.KeyNaming = KeyNamingCamel
+		var user namingUser
+		assert.Error(t, New(s).Decode(context.Background(), strings.NewReader(`{"userId":`), &user))
+		assert.Error(t, New(s).Decode(context.Background(), strings.NewReader(`{"userId":"1"}`), &user))
+	})
+
+	t.Run("test invalid target", func(t *testing.T) {
+		var invalid *json.InvalidUnmarshalError
+		assert.ErrorAs(t, New(DefaultSettings()).Decode(context.Background(), strings.NewReader(`{}`), nil), &invalid)
+		assert.ErrorAs(t, New(DefaultSettings()).Decode(context.Background(), strings.NewReader(`{}`), namingUser{}), &invalid)
+		assert.ErrorAs(t, New(DefaultSettings()).Decode(context.Background(), strings.NewReader(`{}`), (*namingUser)(nil)), &invalid)
+	})
+
+	t.Run("test shared naming", func(t *testing.T) {
+		j := New(DefaultSettings())
+		Must(j.RegisterResponse(Any, ExtKeyNaming(KeyNamingCamel)))
+
+		var user namingUser
+		require.NoError(t, j.Decode(context.Background(), strings.NewReader(`{"userId":1}`), &user))
+		assert.Equal(t, 1, user.UserID)
+	})
+
+	t.Run("test other extensions are not executed", func(t *testing.T) {
+		calls := 0
+		j := New(DefaultSettings())
+		Must(j.RegisterResponse(namingAddress{}, ExtKeyNaming(KeyNamingKebab), ExtFunc(func(ctx context.Context, w http.ResponseWriter) bool {
+			calls++
+			return true
+		}, nil)))
+
+		var address namingAddress
+		require.NoError(t, j.Decode(context.Background(), strings.NewReader(`{"street-name":"Main"}`), &address))
+		assert.Equal(t, "Main", address.StreetName)
+		assert.Zero(t, calls)
+	})
+}
@@ -0,0 +1,53 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Peter Vrba
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package jayson
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"reflect"
+)
+
+// NoContent is response without body, it is rendered with 204 No Content status (ExtStatus can change it,
+// e.g. to 205 Reset Content). Nil response is rendered the same way.
+// Extensions registered for NoContent (and shared ones) are applied to response writer.
+var NoContent = noContent{}
+
+// noContent is type of NoContent
+type noContent struct{}
+
+// renderResponse renders response without body
+func (n noContent) renderResponse(ctx context.Context, j *jayson, rw *responseWriter, override ...Extension) error {
+	rw.WriteHeader(http.StatusNoContent)
+
+	ext, _ := j.getResponseTypeExtensions(reflect.TypeOf(n), override...)
+	newExecutor(ext).ExtendResponseWriter(ctx, rw)
+
+	// body is never written (buffer is cleared if someone mistakenly wrote to it)
+	rw.buffer = bytes.Buffer{}
+
+	return nil
+}
@@ -0,0 +1,125 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Peter Vrba
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package jayson
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestNoContent(t *testing.T) {
+	for _, item := range []struct {
+		name   string
+		what   any
+		ext    []Extension
+		status int
+	}{
+		{name: "test nil", what: nil, status: http.StatusNoContent},
+		{name: "test no content", what: NoContent, status: http.StatusNoContent},
+		{name: "test reset content", what: NoContent, ext: []Extension{ExtStatus(http.StatusResetContent)}, status: http.StatusResetContent},
+	} {
+		t.Run(item.name, func(t *testing.T) {
+			j := New(DefaultSettings())
+			assert.NoError(t, j.RegisterResponse(nil, ExtHeaderValue("X-Registered", "yes")))
+			rw := httptest.NewRecorder()
+			j.Response(context.Background(), rw, item.what, item.ext...)
+			assert.Equal(t, item.status, rw.Code)
+			assert.Empty(t, rw.Body.String())
+			assert.Empty(t, rw.Header().Get("Content-Type"))
+			assert.Equal(t, "yes", rw.Header().Get("X-Registered"))
+		})
+	}
+
+	t.Run("test nested", func(t *testing.T) {
+		j := New(DefaultSettings())
+		rendered := j.RenderResponse(context.Background(), NewOperationSucceeded("1", nil))
+		assert.JSONEq(t, `{"id":"1","status":"succeeded"}`, string(rendered.Body))
+
+		rendered = j.RenderResponse(context.Background(), NewBatch().Add(nil))
+		assert.JSONEq(t, `{"results":[{"status":204}]}`, string(rendered.Body))
+	})
+}
+
+func TestHeadRequest(t *testing.T) {
+	j := New(DefaultSettings())
+	assert.NoError(t, j.RegisterResponse(map[string]string{}, ExtHeaderValue("X-Registered", "yes")))
+
+	body := map[string]string{"hello": strings.Repeat("world", 500)}
+
+	t.Run("test response", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodHead, "/", nil)
+		rw := httptest.NewRecorder()
+		j.Response(ContextWithRequest(r.Context(), r), rw, body)
+
+		get := j.RenderResponse(context.Background(), body)
+
+		assert.Equal(t, http.StatusOK, rw.Code)
+		assert.Empty(t, rw.Body.String())
+		assert.Equal(t, "yes", rw.Header().Get("X-Registered"))
+		assert.Equal(t, "application/json", rw.Header().Get("Content-Type"))
+		assert.Equal(t, strconv.Itoa(len(get.Body)), rw.Header().Get("Content-Length"))
+
+		rendered := j.RenderResponse(ContextWithRequest(r.Context(), r), body)
+		assert.Empty(t, rendered.Body)
+		assert.Equal(t, strconv.Itoa(len(get.Body)), rendered.Header.Get("Content-Length"))
+	})
+
+	t.Run("test compressed", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodHead, "/", nil)
+		r.Header.Set("Accept-Encoding", "gzip")
+		rw := httptest.NewRecorder()
+		j.Response(ContextWithRequest(r.Context(), r), rw, body, ExtCompression(true))
+
+		get := httptest.NewRecorder()
+		r.Method = http.MethodGet
+		j.Response(ContextWithRequest(r.Context(), r), get, body, ExtCompression(true))
+
+		assert.Empty(t, rw.Body.String())
+		assert.Equal(t, "gzip", rw.Header().Get("Content-Encoding"))
+		assert.Equal(t, strconv.Itoa(get.Body.Len()), rw.Header().Get("Content-Length"))
+	})
+
+	t.Run("test error", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodHead, "/", nil)
+		rw := httptest.NewRecorder()
+		j.Error(ContextWithRequest(r.Context(), r), rw, ErrRateLimited)
+		assert.Equal(t, http.StatusTooManyRequests, rw.Code)
+		assert.Empty(t, rw.Body.String())
+		assert.NotEmpty(t, rw.Header().Get("Content-Length"))
+	})
+
+	t.Run("test no content", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodHead, "/", nil)
+		rw := httptest.NewRecorder()
+		j.Response(ContextWithRequest(r.Context(), r), rw, nil)
+		assert.Equal(t, http.StatusNoContent, rw.Code)
+		assert.Empty(t, rw.Header().Get("Content-Length"))
+	})
+}
@@ -0,0 +1,115 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Peter Vrba
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package jayson
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// OperationStatus is status of long-running operation.
+type OperationStatus string
+
+const (
+	// OperationPending is status of operation that is not finished yet.
+	OperationPending OperationStatus = "pending"
+	// OperationSucceeded is status of successfully finished operation.
+	OperationSucceeded OperationStatus = "succeeded"
+	// OperationFailed is status of failed operation.
+	OperationFailed OperationStatus = "failed"
+)
+
+// Operation is a response of long-running (async) operation.
+// Pending operation is rendered with 202 Accepted, Location of operation resource and Retry-After headers,
+// finished operation is rendered with 200 OK and its result or error rendered the same way as it would be rendered
+// by Response or Error (with registered extensions):
+//
+//	{"id": "...", "status": "failed", "error": {"message": "...", "code": 500, ...}}
+type Operation struct {
+	// ID of operation
+	ID string
+	// Status of operation
+	Status OperationStatus
+	// Location is URL of operation resource (rendered for pending operations)
+	Location string
+	// RetryAfter is suggested polling interval (rendered for pending operations)
+	RetryAfter time.Duration
+	// Result of succeeded operation
+	Result any
+	// Err of failed operation
+	Err error
+}
+
+// NewOperationPending returns pending operation with location of operation resource and polling interval.
+func NewOperationPending(id string, location string, retryAfter time.Duration) *Operation {
+	return &Operation{ID: id, Status: OperationPending, Location: location, RetryAfter: retryAfter}
+}
+
+// NewOperationSucceeded returns succeeded operation with result.
+func NewOperationSucceeded(id string, result any) *Operation {
+	return &Operation{ID: id, Status: OperationSucceeded, Result: result}
+}
+
+// NewOperationFailed returns failed operation with error.
+func NewOperationFailed(id string, err error) *Operation {
+	return &Operation{ID: id, Status: OperationFailed, Err: err}
+}
+
+// renderResponse renders operation document with nested result or error
+func (o *Operation) renderResponse(ctx context.Context, j *jayson, rw *responseWriter, override ...Extension) error {
+	status := o.Status
+	if status == "" {
+		status = OperationPending
+	}
+
+	doc := newObject(3)
+	if o.ID != "" {
+		doc.Set("id", o.ID)
+	}
+	doc.Set("status", status)
+
+	switch status {
+	case OperationPending:
+		rw.WriteHeader(http.StatusAccepted)
+		ExtLocation(o.Location).ExtendResponseWriter(ctx, rw)
+		ExtRetryAfter(o.RetryAfter).ExtendResponseWriter(ctx, rw)
+	case OperationSucceeded:
+		rw.WriteHeader(http.StatusOK)
+		if body := j.RenderResponse(nestedContext(ctx), o.Result).Body; len(body) > 0 {
+			doc.Set("result", json.RawMessage(body))
+		}
+	case OperationFailed:
+		rw.WriteHeader(http.StatusOK)
+		if o.Err != nil {
+			doc.Set("error", json.RawMessage(j.RenderError(nestedContext(ctx), o.Err).Body))
+		}
+	}
+
+	j.renderDocument(ctx, rw, o, doc, override...)
+
+	return nil
+}
@@ -0,0 +1,97 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Peter Vrba
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package jayson
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type operationResult struct {
+	URL string `json:"url"`
+}
+
+func TestOperation(t *testing.T) {
+	errExport := errors.New("export failed")
+
+	newJayson := func() Jayson {
+		j := New(DefaultSettings())
+		Must(
+			j.RegisterError(errExport, ExtStatus(http.StatusUnprocessableEntity), ExtErrorCode("export_failed")),
+			j.RegisterResponse(operationResult{}, ExtStatus(http.StatusCreated)),
+		)
+		return j
+	}
+
+	t.Run("test pending", func(t *testing.T) {
+		rendered := newJayson().RenderResponse(context.Background(), NewOperationPending("op1", "/operations/op1", 5*time.Second))
+		assert.Equal(t, http.StatusAccepted, rendered.Status)
+		assert.Equal(t, "/operations/op1", rendered.Header.Get("Location"))
+		assert.Equal(t, "5", rendered.Header.Get("Retry-After"))
+		assert.JSONEq(t, `{"id":"op1","status":"pending"}`, string(rendered.Body))
+
+		rendered = newJayson().RenderResponse(context.Background(), &Operation{})
+		assert.Equal(t, http.StatusAccepted, rendered.Status)
+		assert.Empty(t, rendered.Header.Get("Location"))
+		assert.Empty(t, rendered.Header.Get("Retry-After"))
+		assert.JSONEq(t, `{"status":"pending"}`, string(rendered.Body))
+	})
+
+	t.Run("test succeeded", func(t *testing.T) {
+		rendered := newJayson().RenderResponse(context.Background(), NewOperationSucceeded("op1", operationResult{URL: "/exports/1"}))
+		assert.Equal(t, http.StatusOK, rendered.Status)
+		assert.Empty(t, rendered.Header.Get("Retry-After"))
+		assert.JSONEq(t, `{"id":"op1","status":"succeeded","result":{"url":"/exports/1"}}`, string(rendered.Body))
+
+		rendered = newJayson().RenderResponse(context.Background(), NewOperationSucceeded("op1", nil))
+		assert.JSONEq(t, `{"id":"op1","status":"succeeded"}`, string(rendered.Body))
+	})
+
+	t.Run("test failed", func(t *testing.T) {
+		rendered := newJayson().RenderResponse(context.Background(), NewOperationFailed("op1", errExport))
+		assert.Equal(t, http.StatusOK, rendered.Status)
+		assert.JSONEq(t, `{"id":"op1","status":"failed","error":{"code":422,"error_code":"export_failed","message":"export failed","status":"Unprocessable Entity"}}`, string(rendered.Body))
+	})
+
+	t.Run("test production", func(t *testing.T) {
+		s := DefaultSettings()
+		s.Production = true
+		rendered := New(s).RenderResponse(context.Background(), NewOperationFailed("op1", errors.New("db: connection refused")))
+		assert.NotContains(t, string(rendered.Body), "connection refused")
+	})
+
+	t.Run("test overrides", func(t *testing.T) {
+		j := newJayson()
+		Must(j.RegisterResponse(&Operation{}, ExtHeaderValue("X-Operation", "1")))
+		rendered := j.RenderResponse(context.Background(), NewOperationPending("op1", "", 0), ExtStatus(http.StatusCreated))
+		assert.Equal(t, http.StatusCreated, rendered.Status)
+		assert.Equal(t, "1", rendered.Header.Get("X-Operation"))
+	})
+}
@@ -0,0 +1,267 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Peter Vrba
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package jayson
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// ExtFields is an extension that projects response object to given fields (sparse fieldset).
+// Fields are dotted paths (e.g. "owner.email"), arrays are projected element by element.
+func ExtFields(fields ...string) Extension {
+	return extResponseWriter(func(rw *responseWriter) {
+		rw.options.fields = parseFields(fields)
+	})
+}
+
+// ExtFieldsQuery is an extension that projects response object to fields given in query parameter of request
+// stored in context (e.g. "?fields=id,name,owner.email"), missing or empty parameter does not project anything.
+func ExtFieldsQuery(parameter string) Extension {
+	return ExtFunc(
+		func(ctx context.Context, w http.ResponseWriter) bool {
+			rw, ok := w.(*responseWriter)
+			if !ok {
+				return false
+			}
+			fields := requestFields(ctx, parameter)
+			if len(fields) == 0 {
+				return false
+			}
+			rw.options.fields = fields
+			return true
+		},
+		nil,
+	)
+}
+
+// requestFields returns fields from query parameter of request stored in context
+func requestFields(ctx context.Context, parameter string) []string {
+	if parameter == "" {
+		return nil
+	}
+	r, ok := ContextRequestValue(ctx)
+	if !ok || r.URL == nil {
+		return nil
+	}
+	return parseFields(r.URL.Query()[parameter])
+}
+
+// parseFields splits comma separated fields, empty fields are ignored
+func parseFields(values []string) []string {
+	var result []string
+	for _, value := range values {
+		for _, field := range strings.Split(value, ",") {
+			if field = strings.TrimSpace(field); field != "" {
+				result = append(result, field)
+			}
+		}
+	}
+	return result
+}
+
+// fieldTree is parsed sparse fieldset, nil subtree means that whole value is kept
+type fieldTree map[string]fieldTree
+
+// newFieldTree parses dotted fields into field tree
+func newFieldTree(fields []string) fieldTree {
+	result := make(fieldTree)
+	for _, field := range fields {
+		node := result
+		parts := strings.Split(field, ".")
+		for i, part := range parts {
+			// whole value is requested
+			if i == len(parts)-1 {
+				node[part] = nil
+				break
+			}
+			child, ok := node[part]
+			if ok && child == nil {
+				// whole value was already requested
+				break
+			}
+			if !ok {
+				child = make(fieldTree)
+				node[part] = child
+			}
+			node = child
+		}
+	}
+	return result
+}
+
+// project prunes tree value to fields in field tree
+func (f fieldTree) project(v any) any {
+	switch value := v.(type) {
+	case *object:
+		result := newObject(len(f))
+		for _, key := range value.Keys() {
+			sub, ok := f[key]
+			// HAL links are always kept
+			if !ok && key != linksKey {
+				continue
+			}
+			item, _ := value.Get(key)
+			if sub != nil {
+				item = sub.project(item)
+			}
+			result.Set(key, item)
+		}
+		return result
+	case []any:
+		result := make([]any, len(value))
+		for i, item := range value {
+			result[i] = f.project(item)
+		}
+		return result
+	default:
+		return v
+	}
+}
+
+// validateFields checks that all fields are known (and visible in views) for given value (or any of given types).
+// Fields are given in rendered names (after key naming). It returns ErrUnknownField wrapped with first unknown field.
+func (e encoderOptions) validateFields(v any, types ...reflect.Type) error {
+	for _, field := range e.fields {
+		path := strings.Split(field, ".")
+		if e.knownField(reflect.ValueOf(v), reflect.TypeOf(v), path) {
+			continue
+		}
+		known := false
+		for _, typ := range types {
+			if typ == nil {
+				continue
+			}
+			if base := derefType(typ); base.Kind() == reflect.Struct && e.knownField(reflect.Value{}, typ, path) {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return fmt.Errorf("%w: %s", ErrUnknownField, field)
+		}
+	}
+	return nil
+}
+
+// treeObject returns tree object held by given value
+func treeObject(v reflect.Value) (*object, bool) {
+	if !v.IsValid() || !v.CanInterface() {
+		return nil, false
+	}
+	obj, ok := v.Interface().(*object)
+	return obj, ok && obj != nil
+}
+
+// renderedKey returns key as it is rendered (after key naming)
+func (e encoderOptions) renderedKey(key string) string {
+	if e.keyNaming == nil {
+		return key
+	}
+	return e.keyNaming.Key(key)
+}
+
+// derefType returns type without pointers
+func derefType(typ reflect.Type) reflect.Type {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	return typ
+}
+
+// knownField returns whether path is known in value (when valid) or type, shapes that cannot be known
+// (nil interfaces, marshalers) accept any path
+func (e encoderOptions) knownField(v reflect.Value, typ reflect.Type, path []string) bool {
+	if len(path) == 0 {
+		return true
+	}
+	if v.IsValid() {
+		typ = v.Type()
+	}
+	// rendered tree (e.g. of older API version) is inspected by its keys
+	if obj, ok := treeObject(v); ok {
+		for _, key := range obj.Keys() {
+			if e.renderedKey(key) == path[0] {
+				item, _ := obj.Get(key)
+				return e.knownField(reflect.ValueOf(item), nil, path[1:])
+			}
+		}
+		return false
+	}
+	if typ == nil || isMarshalerType(typ) {
+		return true
+	}
+
+	switch typ.Kind() {
+	case reflect.Pointer:
+		if v.IsValid() && !v.IsNil() {
+			return e.knownField(v.Elem(), nil, path)
+		}
+		return e.knownField(reflect.Value{}, typ.Elem(), path)
+	case reflect.Interface:
+		if v.IsValid() && !v.IsNil() {
+			return e.knownField(v.Elem(), nil, path)
+		}
+		return true
+	case reflect.Struct:
+		for _, field := range cachedStructInfo(typ).fields {
+			if e.renderedKey(field.name) != path[0] || !field.visible(e.views) {
+				continue
+			}
+			if v.IsValid() {
+				if fv, ok := fieldByIndex(v, field.index); ok {
+					return e.knownField(fv, nil, path[1:])
+				}
+			}
+			return e.knownField(reflect.Value{}, typ.FieldByIndex(field.index).Type, path[1:])
+		}
+		return false
+	case reflect.Map:
+		if !v.IsValid() || v.IsNil() {
+			return true
+		}
+		for iter := v.MapRange(); iter.Next(); {
+			if key, ok := mapKeyString(iter.Key()); ok && e.renderedKey(key) == path[0] {
+				return e.knownField(iter.Value(), nil, path[1:])
+			}
+		}
+		return false
+	case reflect.Slice, reflect.Array:
+		if v.IsValid() && v.Len() > 0 {
+			for i := 0; i < v.Len(); i++ {
+				if e.knownField(v.Index(i), nil, path) {
+					return true
+				}
+			}
+			return false
+		}
+		return e.knownField(reflect.Value{}, typ.Elem(), path)
+	default:
+		return false
+	}
+}
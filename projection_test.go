@@ -0,0 +1,187 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Peter Vrba
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package jayson
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type projectionOwner struct {
+	ID    int    `json:"id"`
+	Email string `json:"email"`
+}
+
+type projectionTag struct {
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+type projectionResponse struct {
+	ID      int              `json:"id"`
+	Name    string           `json:"name"`
+	Note    string           `json:"note,omitempty"`
+	Owner   *projectionOwner `json:"owner"`
+	Tags    []projectionTag  `json:"tags"`
+	Created time.Time        `json:"created"`
+}
+
+func TestNewFieldTree(t *testing.T) {
+	assert.Equal(t, fieldTree{"id": nil, "owner": fieldTree{"email": nil}}, newFieldTree([]string{"id", "owner.email"}))
+	assert.Equal(t, fieldTree{"owner": nil}, newFieldTree([]string{"owner", "owner.email"}))
+	assert.Equal(t, fieldTree{"owner": nil}, newFieldTree([]string{"owner.email", "owner"}))
+	assert.Equal(t, fieldTree{"a": fieldTree{"b": fieldTree{"c": nil, "d": nil}}}, newFieldTree([]string{"a.b.c", "a.b.d"}))
+}
+
+func TestParseFields(t *testing.T) {
+	assert.Equal(t, []string{"id", "name", "owner.email"}, parseFields([]string{"id, name,", "owner.email"}))
+	assert.Empty(t, parseFields([]string{""}))
+}
+
+func TestProjection(t *testing.T) {
+	value := projectionResponse{
+		ID:    1,
+		Name:  "jayson",
+		Owner: &projectionOwner{ID: 2, Email: "phonkee@phonkee.eu"},
+		Tags:  []projectionTag{{Name: "go", Color: "blue"}, {Name: "json", Color: "red"}},
+	}
+
+	requestContext := func(query string) context.Context {
+		r := httptest.NewRequest(http.MethodGet, "/?"+query, nil)
+		return ContextWithRequest(r.Context(), r)
+	}
+
+	settings := func() Settings {
+		s := DefaultSettings()
+		s.FieldsQueryParameter = "fields"
+		return s
+	}
+
+	t.Run("test disabled by default", func(t *testing.T) {
+		rendered := New(DefaultSettings()).RenderResponse(requestContext("fields=id"), map[string]any{"id": 1, "name": "jayson"})
+		assert.JSONEq(t, `{"id":1,"name":"jayson"}`, string(rendered.Body))
+	})
+
+	t.Run("test raw struct", func(t *testing.T) {
+		for _, item := range []struct {
+			query    string
+			expected string
+		}{
+			{"", `{"id":1,"name":"jayson","owner":{"id":2,"email":"phonkee@phonkee.eu"},"tags":[{"name":"go","color":"blue"},{"name":"json","color":"red"}],"created":"0001-01-01T00:00:00Z"}`},
+			{"fields=", `{"id":1,"name":"jayson","owner":{"id":2,"email":"phonkee@phonkee.eu"},"tags":[{"name":"go","color":"blue"},{"name":"json","color":"red"}],"created":"0001-01-01T00:00:00Z"}`},
+			{"fields=id,name", `{"id":1,"name":"jayson"}`},
+			{"fields=id,owner.email", `{"id":1,"owner":{"email":"phonkee@phonkee.eu"}}`},
+			{"fields=tags.name", `{"tags":[{"name":"go"},{"name":"json"}]}`},
+			{"fields=owner&fields=created", `{"owner":{"id":2,"email":"phonkee@phonkee.eu"},"created":"0001-01-01T00:00:00Z"}`},
+			{"fields=note", `{}`},
+		} {
+			rendered := New(settings()).RenderResponse(requestContext(item.query), value)
+			assert.Equal(t, http.StatusOK, rendered.Status, item.query)
+			assert.JSONEq(t, item.expected, string(rendered.Body), item.query)
+		}
+	})
+
+	t.Run("test key order is kept", func(t *testing.T) {
+		rendered := New(settings()).RenderResponse(requestContext("fields=name,id"), value)
+		assert.Equal(t, `{"id":1,"name":"jayson"}`+"\n", string(rendered.Body))
+	})
+
+	t.Run("test slice of structs", func(t *testing.T) {
+		rendered := New(settings()).RenderResponse(requestContext("fields=id,owner.id"), []projectionResponse{value, {ID: 3}})
+		assert.JSONEq(t, `[{"id":1,"owner":{"id":2}},{"id":3,"owner":null}]`, string(rendered.Body))
+	})
+
+	t.Run("test object unwrap", func(t *testing.T) {
+		rendered := New(settings()).RenderResponse(
+			requestContext("fields=id,owner.email,extra"),
+			ExtChain(ExtObjectUnwrap(value), ExtObjectKeyValue("extra", 42)),
+		)
+		assert.Equal(t, http.StatusOK, rendered.Status)
+		assert.JSONEq(t, `{"id":1,"owner":{"email":"phonkee@phonkee.eu"},"extra":42}`, string(rendered.Body))
+
+		// omitted field is still known by its type
+		rendered = New(settings()).RenderResponse(requestContext("fields=note"), ExtObjectUnwrap(value))
+		assert.Equal(t, http.StatusOK, rendered.Status)
+		assert.JSONEq(t, `{}`, string(rendered.Body))
+	})
+
+	t.Run("test map", func(t *testing.T) {
+		rendered := New(settings()).RenderResponse(requestContext("fields=user.name"), map[string]any{
+			"user":  map[string]any{"name": "jayson", "password": "secret"},
+			"other": 1,
+		})
+		assert.JSONEq(t, `{"user":{"name":"jayson"}}`, string(rendered.Body))
+	})
+
+	t.Run("test unknown field", func(t *testing.T) {
+		for _, query := range []string{"fields=unknown", "fields=id,owner.unknown", "fields=name.first", "fields=tags.unknown"} {
+			rendered := New(settings()).RenderResponse(requestContext(query), value)
+			assert.Equal(t, http.StatusBadRequest, rendered.Status, query)
+			assert.Contains(t, string(rendered.Body), "unknown field", query)
+		}
+
+		rendered := New(settings()).RenderResponse(requestContext("fields=unknown"), ExtObjectUnwrap(value))
+		assert.Equal(t, http.StatusBadRequest, rendered.Status)
+
+		rendered = New(settings()).RenderResponse(requestContext("fields=unknown"), map[string]any{"id": 1})
+		assert.Equal(t, http.StatusBadRequest, rendered.Status)
+	})
+
+	t.Run("test validate fields", func(t *testing.T) {
+		assert.NoError(t, encoderOptions{}.validateFields(value))
+		assert.NoError(t, encoderOptions{fields: []string{"owner.email"}}.validateFields(projectionResponse{}))
+		assert.NoError(t, encoderOptions{fields: []string{"tags.color"}}.validateFields(projectionResponse{}))
+		assert.NoError(t, encoderOptions{fields: []string{"anything"}}.validateFields(map[string]any(nil)))
+		assert.NoError(t, encoderOptions{fields: []string{"created.anything"}}.validateFields(value))
+
+		err := encoderOptions{fields: []string{"id", "owner.phone"}}.validateFields(value)
+		assert.True(t, errors.Is(err, ErrUnknownField))
+		assert.Equal(t, "unknown field: owner.phone", err.Error())
+	})
+
+	t.Run("test extensions", func(t *testing.T) {
+		rendered := New(DefaultSettings()).RenderResponse(context.Background(), value, ExtFields("id"))
+		assert.JSONEq(t, `{"id":1}`, string(rendered.Body))
+
+		j := New(DefaultSettings())
+		Must(j.RegisterResponse(projectionResponse{}, ExtFieldsQuery("only")))
+		rendered = j.RenderResponse(requestContext("only=name"), value)
+		assert.JSONEq(t, `{"name":"jayson"}`, string(rendered.Body))
+		rendered = j.RenderResponse(requestContext("fields=name"), value)
+		assert.Equal(t, http.StatusOK, rendered.Status)
+		assert.Contains(t, string(rendered.Body), `"owner"`)
+	})
+
+	t.Run("test errors are not projected", func(t *testing.T) {
+		rendered := New(settings()).RenderError(requestContext("fields=id"), ErrPreconditionFailed)
+		assert.Contains(t, string(rendered.Body), `"message"`)
+	})
+}
@@ -0,0 +1,233 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Peter Vrba
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package jayson
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// tokenBucketSweepInterval is the number of takes after which idle (full) buckets are removed.
+	tokenBucketSweepInterval = 1024
+)
+
+// RateLimit is the result of rate limiter for single request.
+type RateLimit struct {
+	// Limit is the maximum number of requests in window (bucket capacity).
+	Limit int
+	// Remaining is the number of requests left.
+	Remaining int
+	// Reset is the time until the limit is fully restored.
+	Reset time.Duration
+	// RetryAfter is the time client should wait before retrying (only when limited).
+	RetryAfter time.Duration
+}
+
+// Header writes RateLimit-* headers (and Retry-After when set) to given header.
+func (r RateLimit) Header(h http.Header) {
+	h.Set("RateLimit-Limit", strconv.Itoa(r.Limit))
+	h.Set("RateLimit-Remaining", strconv.Itoa(max(r.Remaining, 0)))
+	h.Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(r.Reset)))
+	if r.RetryAfter > 0 {
+		h.Set("Retry-After", strconv.Itoa(ceilSeconds(r.RetryAfter)))
+	}
+}
+
+// NewRateLimitError returns error that carries rate limit result, it is matched by ErrRateLimited.
+func NewRateLimitError(rl RateLimit) error {
+	return &rateLimitError{rl: rl}
+}
+
+// rateLimitError is error returned by NewRateLimitError.
+type rateLimitError struct {
+	rl RateLimit
+}
+
+// Error returns message of ErrRateLimited.
+func (r *rateLimitError) Error() string {
+	return ErrRateLimited.Error()
+}
+
+// RateLimit returns rate limit result.
+func (r *rateLimitError) RateLimit() RateLimit {
+	return r.rl
+}
+
+// Unwrap returns ErrRateLimited, so registered extensions are applied.
+func (r *rateLimitError) Unwrap() error {
+	return ErrRateLimited
+}
+
+// ExtRateLimit is an extension that writes RateLimit-* and Retry-After headers.
+// Rate limit result is taken from rendered error (RateLimiter) or from context (ContextWithRateLimit).
+func ExtRateLimit() Extension {
+	return ExtFunc(
+		func(ctx context.Context, w http.ResponseWriter) bool {
+			rl, ok := rateLimitValue(ctx)
+			if !ok {
+				return false
+			}
+			rl.Header(w.Header())
+			return true
+		},
+		nil,
+	)
+}
+
+// rateLimitValue returns rate limit result from rendered error or context.
+func rateLimitValue(ctx context.Context) (RateLimit, bool) {
+	if err, ok := ContextErrorValue(ctx); ok {
+		var limiter RateLimiter
+		if errors.As(err, &limiter) {
+			return limiter.RateLimit(), true
+		}
+	}
+	return ContextRateLimitValue(ctx)
+}
+
+// TokenBucket is in-process token bucket rate limiter with bucket per key.
+type TokenBucket struct {
+	mutex   sync.Mutex
+	limit   int
+	rate    float64
+	buckets map[string]*bucket
+	takes   int
+	now     func() time.Time
+}
+
+// bucket is state of single key.
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// NewTokenBucket returns token bucket that allows limit requests per given period (with burst of limit).
+func NewTokenBucket(limit int, per time.Duration) *TokenBucket {
+	if limit <= 0 || per <= 0 {
+		panic(fmt.Errorf("%w: limit and period must be positive", ErrImproperlyConfigured))
+	}
+	return &TokenBucket{
+		limit:   limit,
+		rate:    float64(limit) / per.Seconds(),
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+// Take takes single token for given key, it returns rate limit result and whether request is allowed.
+func (t *TokenBucket) Take(key string) (RateLimit, bool) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	now := t.now()
+
+	if t.takes++; t.takes%tokenBucketSweepInterval == 0 {
+		t.sweep(now)
+	}
+
+	b, ok := t.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(t.limit), updated: now}
+		t.buckets[key] = b
+	}
+	b.tokens = t.refill(b, now)
+	b.updated = now
+
+	allowed := b.tokens >= 1
+	if allowed {
+		b.tokens--
+	}
+
+	result := RateLimit{
+		Limit:     t.limit,
+		Remaining: int(math.Floor(b.tokens)),
+		Reset:     t.duration(float64(t.limit) - b.tokens),
+	}
+	if !allowed {
+		result.RetryAfter = t.duration(1 - b.tokens)
+	}
+	return result, allowed
+}
+
+// refill returns tokens of bucket at given time.
+func (t *TokenBucket) refill(b *bucket, now time.Time) float64 {
+	return min(float64(t.limit), b.tokens+now.Sub(b.updated).Seconds()*t.rate)
+}
+
+// duration returns time needed to refill given number of tokens.
+func (t *TokenBucket) duration(tokens float64) time.Duration {
+	return time.Duration(tokens / t.rate * float64(time.Second))
+}
+
+// sweep removes buckets that are full again (they are equal to new buckets).
+func (t *TokenBucket) sweep(now time.Time) {
+	for key, b := range t.buckets {
+		if t.refill(b, now) >= float64(t.limit) {
+			delete(t.buckets, key)
+		}
+	}
+}
+
+// RateLimitMiddleware limits requests by token bucket, key function identifies the client (remote host by default).
+// Allowed requests get RateLimit-* headers and result in context, limited requests get ErrRateLimited via Jayson.Error.
+func RateLimitMiddleware(j Jayson, limiter *TokenBucket, key func(*http.Request) string) func(http.Handler) http.Handler {
+	if key == nil {
+		key = remoteHost
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rl, allowed := limiter.Take(key(r))
+			ctx := ContextWithRateLimit(r.Context(), rl)
+			if !allowed {
+				j.Error(ctx, w, NewRateLimitError(rl))
+				return
+			}
+			rl.Header(w.Header())
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// remoteHost returns host part of request remote address.
+func remoteHost(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// ceilSeconds returns duration in whole seconds (rounded up).
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,131 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Peter Vrba
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package jayson
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestExtRateLimit(t *testing.T) {
+	t.Run("test error", func(t *testing.T) {
+		j := New(DefaultSettings())
+		rw := httptest.NewRecorder()
+		err := fmt.Errorf("wrapped: %w", NewRateLimitError(RateLimit{Limit: 10, Remaining: 0, Reset: 30 * time.Second, RetryAfter: 1500 * time.Millisecond}))
+		j.Error(context.Background(), rw, err)
+		assert.Equal(t, http.StatusTooManyRequests, rw.Code)
+		assert.Equal(t, "10", rw.Header().Get("RateLimit-Limit"))
+		assert.Equal(t, "0", rw.Header().Get("RateLimit-Remaining"))
+		assert.Equal(t, "30", rw.Header().Get("RateLimit-Reset"))
+		assert.Equal(t, "2", rw.Header().Get("Retry-After"))
+		assert.True(t, errors.Is(err, ErrRateLimited))
+	})
+
+	t.Run("test context", func(t *testing.T) {
+		j := New(DefaultSettings())
+		rw := httptest.NewRecorder()
+		ctx := ContextWithRateLimit(context.Background(), RateLimit{Limit: 5, Remaining: 3, Reset: time.Second})
+		j.Response(ctx, rw, map[string]any{}, ExtRateLimit())
+		assert.Equal(t, "5", rw.Header().Get("RateLimit-Limit"))
+		assert.Equal(t, "3", rw.Header().Get("RateLimit-Remaining"))
+		assert.Equal(t, "1", rw.Header().Get("RateLimit-Reset"))
+		assert.Empty(t, rw.Header().Get("Retry-After"))
+	})
+
+	t.Run("test missing", func(t *testing.T) {
+		j := New(DefaultSettings())
+		rw := httptest.NewRecorder()
+		j.Error(context.Background(), rw, ErrRateLimited)
+		assert.Equal(t, http.StatusTooManyRequests, rw.Code)
+		assert.Empty(t, rw.Header().Get("RateLimit-Limit"))
+	})
+}
+
+func TestTokenBucket(t *testing.T) {
+	now := time.Unix(1000, 0)
+	tb := NewTokenBucket(2, time.Second)
+	tb.now = func() time.Time { return now }
+
+	rl, ok := tb.Take("a")
+	assert.True(t, ok)
+	assert.Equal(t, RateLimit{Limit: 2, Remaining: 1, Reset: 500 * time.Millisecond}, rl)
+
+	_, ok = tb.Take("a")
+	assert.True(t, ok)
+
+	rl, ok = tb.Take("a")
+	assert.False(t, ok)
+	assert.Equal(t, 0, rl.Remaining)
+	assert.Equal(t, 500*time.Millisecond, rl.RetryAfter)
+
+	_, ok = tb.Take("b")
+	assert.True(t, ok)
+
+	now = now.Add(500 * time.Millisecond)
+	_, ok = tb.Take("a")
+	assert.True(t, ok)
+
+	now = now.Add(time.Hour)
+	tb.sweep(now)
+	assert.Empty(t, tb.buckets)
+
+	assert.PanicsWithError(t, "jayson: improperly configured: limit and period must be positive", func() { NewTokenBucket(0, time.Second) })
+}
+
+func TestRateLimitMiddleware(t *testing.T) {
+	j := New(DefaultSettings())
+	tb := NewTokenBucket(1, time.Minute)
+
+	var stored RateLimit
+	handler := RateLimitMiddleware(j, tb, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		stored, _ = ContextRateLimitValue(r.Context())
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	rw := httptest.NewRecorder()
+	handler.ServeHTTP(rw, r)
+	assert.Equal(t, http.StatusNoContent, rw.Code)
+	assert.Equal(t, "0", rw.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, 1, stored.Limit)
+
+	rw = httptest.NewRecorder()
+	handler.ServeHTTP(rw, r)
+	assert.Equal(t, http.StatusTooManyRequests, rw.Code)
+	assert.Equal(t, "60", rw.Header().Get("Retry-After"))
+	assert.Contains(t, rw.Body.String(), "rate limited")
+
+	// other client is not limited
+	r.RemoteAddr = "10.0.0.1:1234"
+	rw = httptest.NewRecorder()
+	handler.ServeHTTP(rw, r)
+	assert.Equal(t, http.StatusNoContent, rw.Code)
+}
@@ -24,7 +24,11 @@
 
 package jayson
 
-import "sync"
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+)
 
 // newRegistry creates a new registry
 func newRegistry[T comparable]() *registry[T] {
@@ -33,11 +37,23 @@ func newRegistry[T comparable]() *registry[T] {
 	}
 }
 
+// newChildRegistry creates a new registry that falls back to parent registry
+func newChildRegistry[T comparable](parent *registry[T]) *registry[T] {
+	result := newRegistry[T]()
+	result.parent = parent
+	return result
+}
+
 // registry holds ext for given types
 type registry[T comparable] struct {
+	parent *registry[T]
 	shared []Extension
 	items  map[T]*registryItem[T]
 	mutex  sync.RWMutex
+	// seq is the registration order of items
+	seq int
+	// generation is changed on every registration (used to invalidate caches)
+	generation atomic.Uint64
 }
 
 // AddShared adds shared ext
@@ -47,19 +63,31 @@ func (r *registry[T]) AddShared(ext ...Extension) {
 	r.shared = append(r.shared, ext...)
 }
 
-// WithShared prepends shared ext to given ext
+// WithShared prepends shared ext (parent first) to given ext
 func (r *registry[T]) WithShared(ext ...Extension) []Extension {
+	var result []Extension
+	if r.parent != nil {
+		result = r.parent.WithShared()
+	}
+
 	r.mutex.RLock()
 	defer r.mutex.RUnlock()
-	return append(r.shared, ext...)
+
+	result = append(result, r.shared...)
+	return append(result, ext...)
 }
 
-// Exists checks if ext for given type Exists
+// Exists checks if ext for given type Exists (in registry or its parent)
 func (r *registry[T]) Exists(typ T) bool {
 	r.mutex.RLock()
-	defer r.mutex.RUnlock()
+	exists := r.exists(typ)
+	r.mutex.RUnlock()
 
-	return r.exists(typ)
+	if !exists && r.parent != nil {
+		return r.parent.Exists(typ)
+	}
+
+	return exists
 }
 
 // exists checks if ext for given type Exists (without lock)
@@ -68,28 +96,137 @@ func (r *registry[T]) exists(typ T) bool {
 	return ok
 }
 
-// Get return ext for given type if Exists
+// Get return ext for given type if Exists, local registrations take precedence over parent
 func (r *registry[T]) Get(typ T) ([]Extension, bool) {
 	r.mutex.RLock()
-	defer r.mutex.RUnlock()
+	item, ok := r.items[typ]
+	r.mutex.RUnlock()
 
 	// Get ext for given type
-	if item, ok := r.items[typ]; ok {
+	if ok {
 		return item.ext, true
 	}
+	if r.parent != nil {
+		return r.parent.Get(typ)
+	}
 	return nil, false
 }
 
+// Find returns first registered type that matches given function
+// (local registrations first, then parent, in order of registration)
+func (r *registry[T]) Find(fn func(T) bool) (T, bool) {
+	for _, typ := range r.Keys() {
+		if fn(typ) {
+			return typ, true
+		}
+	}
+
+	var zero T
+	return zero, false
+}
+
+// Keys returns registered types in order of registration (local registrations first, then parent)
+func (r *registry[T]) Keys() []T {
+	r.mutex.RLock()
+	items := make([]*registryItem[T], 0, len(r.items))
+	for _, item := range r.items {
+		items = append(items, item)
+	}
+	r.mutex.RUnlock()
+
+	sort.Slice(items, func(i, k int) bool {
+		return items[i].order < items[k].order
+	})
+
+	result := make([]T, 0, len(items))
+	for _, item := range items {
+		result = append(result, item.typ)
+	}
+	if r.parent != nil {
+		result = append(result, r.parent.Keys()...)
+	}
+	return result
+}
+
+// Generation returns number that changes with every registration (in registry or its parent)
+func (r *registry[T]) Generation() uint64 {
+	result := r.generation.Load()
+	if r.parent != nil {
+		result += r.parent.Generation()
+	}
+	return result
+}
+
+// GetAll returns ext for given type from parent and local registry (parent first)
+func (r *registry[T]) GetAll(typ T) ([]Extension, bool) {
+	var (
+		result []Extension
+		found  bool
+	)
+	if r.parent != nil {
+		result, found = r.parent.GetAll(typ)
+	}
+
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	if item, ok := r.items[typ]; ok {
+		result = append(result, item.ext...)
+		found = true
+	}
+	return result, found
+}
+
+// Add appends ext to given type (registers it when it does not exist)
+func (r *registry[T]) Add(typ T, ext ...Extension) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	defer r.generation.Add(1)
+
+	if item, ok := r.items[typ]; ok {
+		item.ext = append(item.ext, ext...)
+		return
+	}
+	r.seq++
+	r.items[typ] = &registryItem[T]{
+		typ:   typ,
+		ext:   ext,
+		order: r.seq,
+	}
+}
+
+// Replace replaces all local registrations with given ones (parent registrations are kept)
+func (r *registry[T]) Replace(items map[T][]Extension) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	defer r.generation.Add(1)
+
+	r.items = make(map[T]*registryItem[T], len(items))
+	for typ, ext := range items {
+		r.seq++
+		r.items[typ] = &registryItem[T]{
+			typ:   typ,
+			ext:   ext,
+			order: r.seq,
+		}
+	}
+}
+
 // Register registers ext for given type
 func (r *registry[T]) Register(typ T, ext []Extension) error {
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
 
+	defer r.generation.Add(1)
+
 	exists := r.exists(typ)
 
+	r.seq++
 	r.items[typ] = &registryItem[T]{
-		typ: typ,
-		ext: ext,
+		typ:   typ,
+		ext:   ext,
+		order: r.seq,
 	}
 
 	// warn if already registered
@@ -102,6 +239,7 @@ func (r *registry[T]) Register(typ T, ext []Extension) error {
 
 // registryItem holds ext for given type
 type registryItem[T comparable] struct {
-	typ T
-	ext []Extension
+	typ   T
+	ext   []Extension
+	order int
 }
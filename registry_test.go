@@ -25,6 +25,7 @@
 package jayson
 
 import (
+	"errors"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -61,3 +62,88 @@ func TestRegistryGet(t *testing.T) {
 	assert.True(t, ok)
 	assert.Equal(t, ext, e)
 }
+
+func TestRegistryChild(t *testing.T) {
+	parent := newRegistry[error]()
+	child := newChildRegistry(parent)
+
+	parentExt := []Extension{ExtNoop()}
+	childExt := []Extension{ExtNoop()}
+	sharedParent := ExtNoop()
+	sharedChild := ExtNoop()
+
+	// parent registrations are visible in child (even when added later)
+	assert.NoError(t, parent.Register(assert.AnError, parentExt))
+	assert.True(t, child.Exists(assert.AnError))
+	e, ok := child.Get(assert.AnError)
+	assert.True(t, ok)
+	assert.Equal(t, parentExt, e)
+
+	// local registration overrides parent and does not warn
+	assert.NoError(t, child.Register(assert.AnError, childExt))
+	e, _ = child.Get(assert.AnError)
+	assert.Same(t, childExt[0], e[0])
+	e, _ = parent.Get(assert.AnError)
+	assert.Same(t, parentExt[0], e[0])
+
+	// shared extensions of parent go first
+	parent.AddShared(sharedParent)
+	child.AddShared(sharedChild)
+	assert.Len(t, child.WithShared(ExtNoop()), 3)
+	assert.Equal(t, sharedParent, child.WithShared()[0])
+	assert.Len(t, parent.WithShared(), 1)
+}
+
+func TestRegistryFind(t *testing.T) {
+	parent := newRegistry[error]()
+	child := newChildRegistry(parent)
+
+	assert.NoError(t, parent.Register(assert.AnError, nil))
+
+	found, ok := child.Find(func(err error) bool { return err == assert.AnError })
+	assert.True(t, ok)
+	assert.Equal(t, assert.AnError, found)
+
+	_, ok = child.Find(func(err error) bool { return false })
+	assert.False(t, ok)
+}
+
+func TestRegistryAdd(t *testing.T) {
+	parent := newRegistry[error]()
+	child := newChildRegistry(parent)
+
+	_, ok := child.GetAll(assert.AnError)
+	assert.False(t, ok)
+
+	parent.Add(assert.AnError, ExtNoop())
+	child.Add(assert.AnError, ExtNoop())
+	child.Add(assert.AnError, ExtNoop())
+
+	ext, ok := child.GetAll(assert.AnError)
+	assert.True(t, ok)
+	assert.Len(t, ext, 3)
+
+	ext, ok = parent.GetAll(assert.AnError)
+	assert.True(t, ok)
+	assert.Len(t, ext, 1)
+}
+
+func TestRegistryKeys(t *testing.T) {
+	first, second := errors.New("first"), errors.New("second")
+
+	parent := newRegistry[error]()
+	child := newChildRegistry(parent)
+	assert.NoError(t, parent.Register(second, nil))
+	assert.NoError(t, child.Register(second, nil))
+	assert.NoError(t, child.Register(first, nil))
+
+	assert.Equal(t, []error{second, first, second}, child.Keys())
+
+	found, ok := child.Find(func(err error) bool { return err == first })
+	assert.True(t, ok)
+	assert.Equal(t, first, found)
+
+	generation := child.Generation()
+	parent.Add(first)
+	assert.NotEqual(t, generation, child.Generation())
+}
@@ -40,13 +40,17 @@ func extWithResponseTypes(extension Extension, typ ...reflect.Type) Extension {
 
 // extResponseType is an extension that provides the response type.
 type extResponseType struct {
-	ext   Extension
-	types []reflect.Type
+	ext    Extension
+	types  []reflect.Type
+	object any
 }
 
 // responseTypeIdentify returns the response type.
 func (e *extResponseType) responseTypes() []reflect.Type { return e.types }
 
+// unwrappedObject returns the object unwrapped by the extension (if any).
+func (e *extResponseType) unwrappedObject() any { return e.object }
+
 // ExtendResponseWriter extends the response writer.
 func (e *extResponseType) ExtendResponseWriter(ctx context.Context, writer http.ResponseWriter) bool {
 	return e.ext.ExtendResponseWriter(ctx, writer)
@@ -28,6 +28,11 @@ import (
 	"net/http"
 )
 
+const (
+	// defaultIndent is default indentation of pretty output
+	defaultIndent = "  "
+)
+
 // DefaultSettings returns default settings for jayson instance
 func DefaultSettings() Settings {
 	return Settings{
@@ -37,10 +42,21 @@ func DefaultSettings() Settings {
 		DefaultErrorStatusTextKey: "status",
 		DefaultResponseStatus:     http.StatusOK,
 		DefaultUnwrapObjectKey:    "object",
+		DefaultErrorIDKey:         "error_id",
+		DefaultErrorDebugKey:      "debug",
+		DefaultErrorCodeKey:       "error_code",
+		DefaultErrorDetailKey:     "detail",
+		Indent:                    defaultIndent,
+		CompressionMinSize:        defaultCompressionMinSize,
+		VersionHeader:             "API-Version",
+		VersionMediaTypeParameter: "version",
 	}
 }
 
 // Settings for jayson instance
+//
+// Keys can be nested paths separated by dot (e.g. "error.message"), which render nested objects.
+// Keys set to KeyDisabled are not rendered at all.
 type Settings struct {
 	DefaultErrorStatus        int
 	DefaultErrorMessageKey    string
@@ -48,6 +64,60 @@ type Settings struct {
 	DefaultErrorStatusTextKey string
 	DefaultResponseStatus     int
 	DefaultUnwrapObjectKey    string // if unwrap fails, object will be placed under this key
+	DefaultErrorIDKey         string // key for generated error id (only in production mode)
+	DefaultErrorDebugKey      string // key for debug information rendered by ExtErrorDebug
+	DefaultErrorCodeKey       string // key for application error code (see ErrorCoder)
+	DefaultErrorDetailKey     string // key for error detail (see ExtErrorDetail)
+
+	// Production hides messages of unregistered errors and all 5xx errors from the client.
+	// Real error is logged under generated error id, which is also written to the response.
+	Production bool
+	// ProductionErrorMessage replaces message of hidden errors (http status text is used when empty)
+	ProductionErrorMessage string
+
+	// DebugErrors enables ExtErrorDebug for all requests, it is always disabled in production mode.
+	DebugErrors bool
+
+	// Pretty enables indentation of encoded JSON.
+	Pretty bool
+	// PrettyQueryParameter enables indentation when the query parameter is present (e.g. ?pretty), request
+	// must be available in context (see RequestMiddleware).
+	PrettyQueryParameter string
+	// Indent is used for pretty output.
+	Indent string
+	// DisableHTMLEscape disables escaping of HTML characters (<, >, &) in encoded JSON strings.
+	DisableHTMLEscape bool
+	// Int64AsString encodes int64, uint64, int, uint and uintptr values outside of ±(2^53-1) as strings
+	// (JavaScript numbers cannot represent them), smaller values stay numbers.
+	Int64AsString bool
+	// NilSliceAsEmpty encodes nil slices as [] instead of null.
+	NilSliceAsEmpty bool
+
+	// ETag computes strong ETag from encoded body of successful responses (see also ETagger).
+	ETag bool
+
+	// Compression enables gzip/deflate compression of response body negotiated by Accept-Encoding.
+	Compression bool
+	// CompressionMinSize is minimal size of body (in bytes) to be compressed.
+	CompressionMinSize int
+
+	// FieldsQueryParameter is query parameter with sparse fieldset of responses (e.g. "fields"), empty disables it.
+	FieldsQueryParameter string
+
+	// KeyNaming rewrites keys of rendered objects (e.g. KeyNamingCamel), nil keeps keys as they are.
+	KeyNaming KeyNaming
+
+	// RouteResolver resolves named routes of HAL links (see ExtLinkRoute).
+	RouteResolver RouteResolver
+
+	// JSONAPI renders responses and errors as JSON:API documents.
+	JSONAPI bool
+
+	// VersionHeader is request header with requested API version (see RegisterResponseVersion), empty disables it.
+	VersionHeader string
+	// VersionMediaTypeParameter is media type parameter of Accept header with requested API version
+	// (e.g. "application/json; version=2024-01-01"), empty disables it.
+	VersionMediaTypeParameter string
 }
 
 func (s *Settings) Validate() {
@@ -69,4 +139,37 @@ func (s *Settings) Validate() {
 	if s.DefaultUnwrapObjectKey == "" {
 		s.DefaultUnwrapObjectKey = "object"
 	}
+	if s.DefaultErrorIDKey == "" {
+		s.DefaultErrorIDKey = "error_id"
+	}
+	if s.DefaultErrorDebugKey == "" {
+		s.DefaultErrorDebugKey = "debug"
+	}
+	if s.DefaultErrorCodeKey == "" {
+		s.DefaultErrorCodeKey = "error_code"
+	}
+	if s.DefaultErrorDetailKey == "" {
+		s.DefaultErrorDetailKey = "detail"
+	}
+	if s.Indent == "" {
+		s.Indent = defaultIndent
+	}
+	if s.CompressionMinSize <= 0 {
+		s.CompressionMinSize = defaultCompressionMinSize
+	}
+	// debug information must never leak in production mode
+	if s.Production {
+		s.DebugErrors = false
+	}
+}
+
+// productionErrorMessage returns message that replaces hidden error message
+func (s *Settings) productionErrorMessage(status int) string {
+	if s.ProductionErrorMessage != "" {
+		return s.ProductionErrorMessage
+	}
+	if text := http.StatusText(status); text != "" {
+		return text
+	}
+	return http.StatusText(http.StatusInternalServerError)
 }
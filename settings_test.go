@@ -39,4 +39,28 @@ func TestSettings_Validate(t *testing.T) {
 	assert.Equal(t, "code", s.DefaultErrorStatusCodeKey)
 	assert.Equal(t, "status", s.DefaultErrorStatusTextKey)
 	assert.Equal(t, http.StatusOK, s.DefaultResponseStatus)
+	assert.Equal(t, "error_id", s.DefaultErrorIDKey)
+	assert.Equal(t, "debug", s.DefaultErrorDebugKey)
+	assert.Equal(t, "error_code", s.DefaultErrorCodeKey)
+	assert.Equal(t, "detail", s.DefaultErrorDetailKey)
+	assert.Equal(t, "  ", s.Indent)
+
+	s = Settings{Production: true, DebugErrors: true}
+	s.Validate()
+	assert.False(t, s.DebugErrors)
+}
+
+func TestSettings_productionErrorMessage(t *testing.T) {
+	s := DefaultSettings()
+	assert.Equal(t, "Bad Gateway", s.productionErrorMessage(http.StatusBadGateway))
+	assert.Equal(t, "Internal Server Error", s.productionErrorMessage(999))
+
+	s.ProductionErrorMessage = "oops"
+	assert.Equal(t, "oops", s.productionErrorMessage(http.StatusBadGateway))
+}
+
+func TestSettings_Validate_KeyDisabled(t *testing.T) {
+	s := Settings{DefaultErrorStatusTextKey: KeyDisabled}
+	s.Validate()
+	assert.Equal(t, KeyDisabled, s.DefaultErrorStatusTextKey)
 }
@@ -0,0 +1,72 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Peter Vrba
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package jayson
+
+import (
+	"reflect"
+	"strings"
+)
+
+const (
+	// tagName is name of jayson struct tag (e.g. `jayson:"view=admin|support"`)
+	tagName = "jayson"
+)
+
+// tagOptions are parsed options of jayson struct tag
+type tagOptions struct {
+	// views restrict field to given views (`view=admin|support`)
+	views []string
+	// id marks JSON:API resource id field (`id`)
+	id bool
+	// resourceType is JSON:API resource type (`type=users`, used on id field)
+	resourceType string
+	// relationship marks JSON:API relationship field (`relationship`)
+	relationship bool
+}
+
+// parseTag parses jayson struct tag, options are separated by comma.
+func parseTag(tag reflect.StructTag) tagOptions {
+	var result tagOptions
+	for _, option := range strings.Split(tag.Get(tagName), ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(option), "=")
+		switch key {
+		case "view":
+			for _, view := range strings.Split(value, "|") {
+				if view = strings.TrimSpace(view); view != "" {
+					result.views = append(result.views, view)
+				}
+			}
+		case "id":
+			result.id = true
+		case "type":
+			result.resourceType = strings.TrimSpace(value)
+		case "relationship":
+			result.relationship = true
+		default:
+			// unknown options are ignored
+		}
+	}
+	return result
+}
@@ -0,0 +1,50 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Peter Vrba
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package jayson
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTag(t *testing.T) {
+	for _, item := range []struct {
+		tag      reflect.StructTag
+		expected tagOptions
+	}{
+		{``, tagOptions{}},
+		{`json:"id"`, tagOptions{}},
+		{`jayson:"view=admin"`, tagOptions{views: []string{"admin"}}},
+		{`jayson:"view=admin|support"`, tagOptions{views: []string{"admin", "support"}}},
+		{`jayson:"other,view=admin"`, tagOptions{views: []string{"admin"}}},
+		{`jayson:"view="`, tagOptions{}},
+		{`jayson:"id,type=users"`, tagOptions{id: true, resourceType: "users"}},
+		{`jayson:"relationship,view=admin"`, tagOptions{relationship: true, views: []string{"admin"}}},
+	} {
+		assert.Equal(t, item.expected, parseTag(item.tag), string(item.tag))
+	}
+}
@@ -0,0 +1,122 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Peter Vrba
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package jayson
+
+import (
+	"context"
+	"reflect"
+)
+
+// transformFunc transforms raw response value before it is encoded
+type transformFunc func(context.Context, any) any
+
+// ExtTransform is an extension that transforms raw response value of type T (also *T and slices/arrays/maps of them)
+// before it is encoded (e.g. to clear secrets or compute derived fields).
+// Function receives a copy of the value, so the original value is not altered (unless T is a pointer).
+func ExtTransform[T any](fn func(context.Context, T) T) Extension {
+	return extTransform(func(ctx context.Context, v any) any {
+		return transformTyped(ctx, v, fn)
+	})
+}
+
+// ExtTransformAny is an extension that transforms whole raw response value before it is encoded.
+func ExtTransformAny(fn func(context.Context, any) any) Extension {
+	return extTransform(fn)
+}
+
+// extTransform adds transform function to response writer
+func extTransform(fn transformFunc) Extension {
+	return extResponseWriter(func(rw *responseWriter) {
+		if fn != nil {
+			rw.transforms = append(rw.transforms, fn)
+		}
+	})
+}
+
+// transform applies transform functions to raw response value (in order they were added)
+func (r *responseWriter) transform(ctx context.Context, what any) any {
+	for _, fn := range r.transforms {
+		what = fn(ctx, what)
+	}
+	return what
+}
+
+// transformTyped applies function to value of type T, *T or to items of slice/array/map of them
+func transformTyped[T any](ctx context.Context, v any, fn func(context.Context, T) T) any {
+	switch typed := v.(type) {
+	case T:
+		return fn(ctx, typed)
+	case *T:
+		if typed == nil {
+			return v
+		}
+		result := fn(ctx, *typed)
+		return &result
+	}
+
+	val := reflect.ValueOf(v)
+	typ := reflect.TypeFor[T]()
+
+	var result reflect.Value
+	switch val.Kind() {
+	case reflect.Slice:
+		if val.IsNil() {
+			return v
+		}
+		result = reflect.MakeSlice(val.Type(), val.Len(), val.Len())
+	case reflect.Array:
+		result = reflect.New(val.Type()).Elem()
+	case reflect.Map:
+		if val.IsNil() {
+			return v
+		}
+		result = reflect.MakeMapWithSize(val.Type(), val.Len())
+	default:
+		return v
+	}
+
+	// only slices (and maps) of T (or *T) are transformed, other slices are kept as they are
+	if elem := val.Type().Elem(); elem != typ && elem != reflect.PointerTo(typ) {
+		return v
+	}
+
+	if val.Kind() == reflect.Map {
+		for iter := val.MapRange(); iter.Next(); {
+			item := reflect.Zero(val.Type().Elem())
+			if transformed := transformTyped(ctx, iter.Value().Interface(), fn); transformed != nil {
+				item = reflect.ValueOf(transformed)
+			}
+			result.SetMapIndex(iter.Key(), item)
+		}
+		return result.Interface()
+	}
+
+	for i := 0; i < val.Len(); i++ {
+		if item := transformTyped(ctx, val.Index(i).Interface(), fn); item != nil {
+			result.Index(i).Set(reflect.ValueOf(item))
+		}
+	}
+	return result.Interface()
+}
@@ -0,0 +1,89 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Peter Vrba
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package jayson
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+)
+
+type transformUser struct {
+	ID       int    `json:"id"`
+	Password string `json:"password,omitempty"`
+	Name     string `json:"name"`
+}
+
+func TestExtTransform(t *testing.T) {
+	clearPassword := ExtTransform(func(ctx context.Context, u transformUser) transformUser {
+		u.Password = ""
+		return u
+	})
+
+	user := transformUser{ID: 1, Password: "secret", Name: "peter"}
+
+	for _, item := range []struct {
+		name   string
+		what   any
+		expect string
+	}{
+		{name: "test value", what: user, expect: `{"id":1,"name":"peter"}`},
+		{name: "test pointer", what: &user, expect: `{"id":1,"name":"peter"}`},
+		{name: "test slice", what: []transformUser{user, user}, expect: `[{"id":1,"name":"peter"},{"id":1,"name":"peter"}]`},
+		{name: "test slice of pointers", what: []*transformUser{&user, nil}, expect: `[{"id":1,"name":"peter"},null]`},
+		{name: "test array", what: [1]transformUser{user}, expect: `[{"id":1,"name":"peter"}]`},
+		{name: "test map", what: map[string]transformUser{"a": user}, expect: `{"a":{"id":1,"name":"peter"}}`},
+		{name: "test map of pointers", what: map[int]*transformUser{1: &user, 2: nil}, expect: `{"1":{"id":1,"name":"peter"},"2":null}`},
+		{name: "test other type", what: map[string]string{"password": "secret"}, expect: `{"password":"secret"}`},
+	} {
+		t.Run(item.name, func(t *testing.T) {
+			j := New(DefaultSettings())
+			assert.NoError(t, j.RegisterResponse(transformUser{}, clearPassword))
+			assert.NoError(t, j.RegisterResponse(map[string]string{}, clearPassword))
+			rw := httptest.NewRecorder()
+			j.Response(context.Background(), rw, item.what)
+			assert.JSONEq(t, item.expect, rw.Body.String())
+		})
+	}
+
+	// original value is not altered
+	assert.Equal(t, "secret", user.Password)
+}
+
+func TestExtTransformAny(t *testing.T) {
+	j := New(DefaultSettings())
+	rw := httptest.NewRecorder()
+	j.Response(context.Background(), rw, transformUser{ID: 1, Name: "peter"},
+		ExtTransformAny(func(ctx context.Context, v any) any {
+			return map[string]any{"user": v}
+		}),
+		ExtTransform(func(ctx context.Context, m map[string]any) map[string]any {
+			m["count"] = 1
+			return m
+		}),
+	)
+	assert.JSONEq(t, `{"user":{"id":1,"name":"peter"},"count":1}`, rw.Body.String())
+}
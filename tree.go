@@ -0,0 +1,493 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Peter Vrba
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package jayson
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	// maxTreeDepth protects tree conversion from cyclic values (encoding/json reports them later)
+	maxTreeDepth = 1000
+
+	// maxSafeInteger is the largest integer that JavaScript number represents exactly
+	maxSafeInteger = 1<<53 - 1
+)
+
+var (
+	marshalerType     = reflect.TypeFor[json.Marshaler]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+	unmarshalerType   = reflect.TypeFor[json.Unmarshaler]()
+
+	// structInfoCache caches json metadata of struct types
+	structInfoCache sync.Map
+)
+
+// treeOptions are options for conversion of values to tree
+type treeOptions struct {
+	int64AsString   bool
+	nilSliceAsEmpty bool
+	views           []string
+}
+
+// toTree converts value to tree of *object, []any and leaf values that encodes the same way as encoding/json
+// would encode the value, but it allows further inspection and alteration of encoded value.
+// Values implementing json.Marshaler or encoding.TextMarshaler are kept as leaves.
+func toTree(v reflect.Value, opts treeOptions, depth int) any {
+	if !v.IsValid() {
+		return nil
+	}
+	if depth > maxTreeDepth {
+		return v.Interface()
+	}
+
+	// marshalers are leaves
+	if leaf, ok := marshalerLeaf(v); ok {
+		return leaf
+	}
+
+	switch v.Kind() {
+	case reflect.Interface, reflect.Pointer:
+		if v.IsNil() {
+			return nil
+		}
+		return toTree(v.Elem(), opts, depth+1)
+	case reflect.Struct:
+		info := cachedStructInfo(v.Type())
+		result := newObject(len(info.fields))
+		for _, field := range info.fields {
+			if !field.visible(opts.views) {
+				continue
+			}
+			fv, ok := fieldByIndex(v, field.index)
+			if !ok {
+				continue
+			}
+			if field.omitEmpty && isEmptyValue(fv) {
+				continue
+			}
+			if field.omitZero && fv.IsZero() {
+				continue
+			}
+			if field.asString {
+				if value, ok := stringOptionValue(fv); ok {
+					result.Set(field.name, value)
+					continue
+				}
+			}
+			result.Set(field.name, toTree(fv, opts, depth+1))
+		}
+		return result
+	case reflect.Map:
+		if v.IsNil() {
+			return nil
+		}
+		keys := make([]string, 0, v.Len())
+		values := make(map[string]reflect.Value, v.Len())
+		for iter := v.MapRange(); iter.Next(); {
+			key, ok := mapKeyString(iter.Key())
+			if !ok {
+				// encoding/json will report unsupported key
+				return v.Interface()
+			}
+			keys = append(keys, key)
+			values[key] = iter.Value()
+		}
+		sort.Strings(keys)
+		result := newObject(len(keys))
+		for _, key := range keys {
+			result.Set(key, toTree(values[key], opts, depth+1))
+		}
+		return result
+	case reflect.Slice:
+		if v.IsNil() {
+			if opts.nilSliceAsEmpty {
+				return []any{}
+			}
+			return nil
+		}
+		// byte slices are encoded as base64 strings
+		if v.Type().Elem().Kind() == reflect.Uint8 && !isMarshalerType(v.Type().Elem()) {
+			return v.Interface()
+		}
+		fallthrough
+	case reflect.Array:
+		result := make([]any, v.Len())
+		for i := range result {
+			result[i] = toTree(v.Index(i), opts, depth+1)
+		}
+		return result
+	// only integers that JavaScript numbers cannot represent are converted (int and uint can be 64-bit too),
+	// so status codes and other small numbers stay numbers
+	case reflect.Int, reflect.Int64:
+		if opts.int64AsString && (v.Int() > maxSafeInteger || v.Int() < -maxSafeInteger) {
+			return strconv.FormatInt(v.Int(), 10)
+		}
+	case reflect.Uint, reflect.Uint64, reflect.Uintptr:
+		if opts.int64AsString && v.Uint() > maxSafeInteger {
+			return strconv.FormatUint(v.Uint(), 10)
+		}
+	default:
+		// no-op
+	}
+
+	return v.Interface()
+}
+
+// marshalerLeaf returns value that should be encoded by its own marshaler
+func marshalerLeaf(v reflect.Value) (any, bool) {
+	typ := v.Type()
+	if typ.Kind() == reflect.Pointer && v.IsNil() && isMarshalerType(typ) {
+		return nil, true
+	}
+	if isMarshalerType(typ) {
+		return v.Interface(), true
+	}
+	if v.CanAddr() && isMarshalerType(reflect.PointerTo(typ)) {
+		return v.Addr().Interface(), true
+	}
+	return nil, false
+}
+
+// isMarshalerType returns whether type provides its own JSON encoding
+func isMarshalerType(typ reflect.Type) bool {
+	return typ.Implements(marshalerType) || typ.Implements(textMarshalerType)
+}
+
+// mapKeyString returns string representation of map key the same way as encoding/json does
+func mapKeyString(key reflect.Value) (string, bool) {
+	if key.Kind() == reflect.String {
+		return key.String(), true
+	}
+	if tm, ok := key.Interface().(encoding.TextMarshaler); ok {
+		if key.Kind() == reflect.Pointer && key.IsNil() {
+			return "", true
+		}
+		text, err := tm.MarshalText()
+		return string(text), err == nil
+	}
+	switch key.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(key.Int(), 10), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(key.Uint(), 10), true
+	default:
+		return "", false
+	}
+}
+
+// stringOptionValue returns value for fields with `,string` json option, unnamed pointers are dereferenced
+// and values with their own marshalers are not quoted (as in encoding/json)
+func stringOptionValue(v reflect.Value) (string, bool) {
+	if v.Kind() == reflect.Pointer && v.Type().Name() == "" {
+		if v.IsNil() || isMarshalerType(v.Type()) {
+			return "", false
+		}
+		v = v.Elem()
+	}
+	if _, ok := marshalerLeaf(v); ok {
+		return "", false
+	}
+	switch v.Kind() {
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.String:
+		encoded, err := json.Marshal(v.Interface())
+		return string(encoded), err == nil
+	default:
+		return "", false
+	}
+}
+
+// fieldByIndex returns nested field, it returns false when embedded pointer is nil
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+// structInfo is cached json metadata of struct type
+type structInfo struct {
+	fields []structField
+}
+
+// structField is json metadata of single (possibly promoted) struct field
+type structField struct {
+	name      string
+	index     []int
+	tag       reflect.StructTag
+	tagged    bool
+	omitEmpty bool
+	omitZero  bool
+	asString  bool
+	jayson    tagOptions
+}
+
+// cachedStructInfo returns json metadata of struct type
+func cachedStructInfo(typ reflect.Type) *structInfo {
+	if info, ok := structInfoCache.Load(typ); ok {
+		return info.(*structInfo)
+	}
+	info, _ := structInfoCache.LoadOrStore(typ, &structInfo{fields: typeFields(typ)})
+	return info.(*structInfo)
+}
+
+// typeFields returns fields that encoding/json would encode for given struct type (with promoted embedded fields)
+func typeFields(typ reflect.Type) []structField {
+	type candidate struct {
+		structField
+		depth int
+	}
+
+	type embedded struct {
+		typ   reflect.Type
+		index []int
+	}
+
+	var (
+		candidates []candidate
+		current    = []embedded{{typ: typ}}
+		// types walked at shallower depths are skipped, type embedded more than once at the same depth
+		// is walked for each occurrence, so its fields conflict (as in encoding/json)
+		visited = make(map[reflect.Type]bool)
+	)
+
+	for depth := 0; len(current) > 0; depth++ {
+		var next []embedded
+		for _, e := range current {
+			if visited[e.typ] {
+				continue
+			}
+			for i := 0; i < e.typ.NumField(); i++ {
+				sf := e.typ.Field(i)
+				tag := sf.Tag.Get("json")
+				if tag == "-" {
+					continue
+				}
+
+				name, options, _ := strings.Cut(tag, ",")
+				fieldIndex := append(append([]int(nil), e.index...), i)
+
+				if sf.Anonymous {
+					ft := sf.Type
+					if ft.Kind() == reflect.Pointer {
+						ft = ft.Elem()
+					}
+					if !sf.IsExported() && ft.Kind() != reflect.Struct {
+						continue
+					}
+					// untagged embedded struct fields are promoted
+					if name == "" && ft.Kind() == reflect.Struct {
+						next = append(next, embedded{typ: ft, index: fieldIndex})
+						continue
+					}
+				} else if !sf.IsExported() {
+					continue
+				}
+
+				field := structField{
+					name:   name,
+					index:  fieldIndex,
+					tag:    sf.Tag,
+					tagged: name != "",
+					jayson: parseTag(sf.Tag),
+				}
+				if field.name == "" {
+					field.name = sf.Name
+				}
+				for _, option := range strings.Split(options, ",") {
+					switch option {
+					case "omitempty":
+						field.omitEmpty = true
+					case "omitzero":
+						field.omitZero = true
+					case "string":
+						field.asString = true
+					}
+				}
+
+				candidates = append(candidates, candidate{structField: field, depth: depth})
+			}
+		}
+		for _, e := range current {
+			visited[e.typ] = true
+		}
+		current = next
+	}
+
+	// group candidates by name (in order of appearance)
+	var (
+		names  []string
+		byName = make(map[string][]candidate)
+	)
+	for _, c := range candidates {
+		if _, ok := byName[c.name]; !ok {
+			names = append(names, c.name)
+		}
+		byName[c.name] = append(byName[c.name], c)
+	}
+
+	// dominant field wins (shallowest, then tagged), ambiguous fields are dropped
+	result := make([]structField, 0, len(names))
+	for _, name := range names {
+		group := byName[name]
+		minDepth := group[0].depth
+		for _, c := range group {
+			minDepth = min(minDepth, c.depth)
+		}
+
+		var dominant []candidate
+		for _, c := range group {
+			if c.depth == minDepth {
+				dominant = append(dominant, c)
+			}
+		}
+		if len(dominant) > 1 {
+			var tagged []candidate
+			for _, c := range dominant {
+				if c.tagged {
+					tagged = append(tagged, c)
+				}
+			}
+			dominant = tagged
+		}
+		if len(dominant) == 1 {
+			result = append(result, dominant[0].structField)
+		}
+	}
+
+	// encoding/json orders fields by their index sequence
+	sort.SliceStable(result, func(i, j int) bool {
+		return indexLess(result[i].index, result[j].index)
+	})
+
+	return result
+}
+
+// indexLess compares field index sequences
+func indexLess(a, b []int) bool {
+	for k := range min(len(a), len(b)) {
+		if a[k] != b[k] {
+			return a[k] < b[k]
+		}
+	}
+	return len(a) < len(b)
+}
+
+// newObject creates new ordered JSON object
+func newObject(size int) *object {
+	return &object{
+		keys:   make([]string, 0, size),
+		values: make(map[string]any, size),
+	}
+}
+
+// object is JSON object that keeps insertion order of its keys
+type object struct {
+	keys   []string
+	values map[string]any
+}
+
+// Set sets value for given key (new keys are appended)
+func (o *object) Set(key string, value any) {
+	if _, ok := o.values[key]; !ok {
+		o.keys = append(o.keys, key)
+	}
+	o.values[key] = value
+}
+
+// Get returns value for given key
+func (o *object) Get(key string) (any, bool) {
+	value, ok := o.values[key]
+	return value, ok
+}
+
+// Delete deletes given key, it returns whether the key was present
+func (o *object) Delete(key string) bool {
+	if _, ok := o.values[key]; !ok {
+		return false
+	}
+	delete(o.values, key)
+	for i, k := range o.keys {
+		if k == key {
+			o.keys = append(o.keys[:i], o.keys[i+1:]...)
+			break
+		}
+	}
+	return true
+}
+
+// Keys returns keys in order
+func (o *object) Keys() []string {
+	return o.keys
+}
+
+// Len returns number of keys
+func (o *object) Len() int {
+	return len(o.keys)
+}
+
+// MarshalJSON encodes object with keys in order.
+// HTML characters are escaped (and output is indented) by the outer encoder.
+func (o *object) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+
+	buf.WriteByte('{')
+	for i, key := range o.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if err := enc.Encode(key); err != nil {
+			return nil, err
+		}
+		buf.Truncate(buf.Len() - 1)
+		buf.WriteByte(':')
+		if err := enc.Encode(o.values[key]); err != nil {
+			return nil, err
+		}
+		buf.Truncate(buf.Len() - 1)
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,223 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Peter Vrba
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package jayson
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type treeEmbedded struct {
+	Shared   string `json:"shared"`
+	Promoted int
+}
+
+type TreeExported struct {
+	Exported bool `json:"exported"`
+}
+
+type treeConflictA struct{ Conflict int }
+type treeConflictB struct{ Conflict int }
+
+type treeDiamondC struct {
+	X int `json:"x"`
+}
+type treeDiamondA struct{ treeDiamondC }
+type treeDiamondB struct{ treeDiamondC }
+
+type treeQuotedMarshaler int
+
+func (t treeQuotedMarshaler) MarshalJSON() ([]byte, error) { return []byte(`"marshaled"`), nil }
+
+type treeDiamond struct {
+	treeDiamondA
+	treeDiamondB
+	P      *int                 `json:"p,string"`
+	Nil    *int                 `json:"nil,string"`
+	Bool   *bool                `json:"bool,string"`
+	Own    treeQuotedMarshaler  `json:"own,string"`
+	OwnPtr *treeQuotedMarshaler `json:"own_ptr,string"`
+	Items  []string             `json:"items"`
+}
+
+type treeTextKey int
+
+func (t treeTextKey) MarshalText() ([]byte, error) {
+	return []byte("key-" + string(rune('a'+int(t)))), nil
+}
+
+type treePointerMarshaler struct{ value string }
+
+func (t *treePointerMarshaler) MarshalJSON() ([]byte, error) { return json.Marshal("ptr:" + t.value) }
+
+type treeStruct struct {
+	Name       string    `json:"name"`
+	Skip       string    `json:"-"`
+	Dash       string    `json:"-,"`
+	Empty      string    `json:"empty,omitempty"`
+	Zero       time.Time `json:"zero,omitzero"`
+	Quoted     int       `json:"quoted,string"`
+	Untagged   float64
+	Time       time.Time            `json:"time"`
+	IP         net.IP               `json:"ip"`
+	Bytes      []byte               `json:"bytes"`
+	Nil        []int                `json:"nil"`
+	Ptr        *int                 `json:"ptr"`
+	Map        map[string]any       `json:"map"`
+	IntMap     map[int]string       `json:"int_map"`
+	TextMap    map[treeTextKey]int  `json:"text_map"`
+	Array      [2]uint8             `json:"array"`
+	Iface      any                  `json:"iface"`
+	Marshaler  treePointerMarshaler `json:"marshaler"`
+	Shared     string               `json:"shared"`
+	unexported string
+	treeEmbedded
+	*TreeExported
+	treeConflictA
+	treeConflictB
+}
+
+func TestToTree(t *testing.T) {
+	answer := 42
+	values := []any{
+		nil,
+		42,
+		"string",
+		[]int{1, 2, 3},
+		map[string]int{"b": 2, "a": 1},
+		treeStruct{
+			Name:    "name",
+			Dash:    "dash",
+			Quoted:  7,
+			Time:    time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			IP:      net.IPv4(127, 0, 0, 1),
+			Bytes:   []byte("hello"),
+			Ptr:     &answer,
+			Map:     map[string]any{"z": []any{1, "x"}, "a": nil},
+			IntMap:  map[int]string{2: "two", 10: "ten"},
+			TextMap: map[treeTextKey]int{1: 1, 0: 0},
+			Iface:   map[string]int{"a": 1},
+			Shared:  "outer",
+			treeEmbedded: treeEmbedded{
+				Shared:   "inner",
+				Promoted: 1,
+			},
+			TreeExported: &TreeExported{Exported: true},
+		},
+		&treeStruct{Marshaler: treePointerMarshaler{value: "x"}},
+		[]*treeStruct{nil, {Name: "second"}},
+	}
+
+	for _, value := range values {
+		expected, err := json.Marshal(value)
+		require.NoError(t, err)
+
+		got, err := json.Marshal(toTree(reflect.ValueOf(value), treeOptions{}, 0))
+		require.NoError(t, err)
+
+		// byte-wise equality also checks order of keys
+		assert.Equal(t, string(expected), string(got))
+	}
+
+	t.Run("test conflicting embedded fields and quoted pointers", func(t *testing.T) {
+		answer, yes, own := 5, true, treeQuotedMarshaler(1)
+		value := treeDiamond{P: &answer, Bool: &yes, OwnPtr: &own, Items: []string{"a"}}
+
+		expected, err := json.Marshal(value)
+		require.NoError(t, err)
+		assert.Equal(t, `{"p":"5","nil":null,"bool":"true","own":"marshaled","own_ptr":"marshaled","items":["a"]}`, string(expected))
+
+		// options unrelated to these fields do not change output
+		for _, opts := range []treeOptions{{}, {nilSliceAsEmpty: true}, {int64AsString: true}} {
+			got, err := json.Marshal(toTree(reflect.ValueOf(value), opts, 0))
+			require.NoError(t, err)
+			assert.Equal(t, string(expected), string(got))
+		}
+	})
+}
+
+func TestToTree_Options(t *testing.T) {
+	type options struct {
+		Big    int64          `json:"big"`
+		Small  int32          `json:"small"`
+		Neg    int64          `json:"neg"`
+		Ubig   uint64         `json:"ubig"`
+		Int    int            `json:"int"`
+		Uint   uint           `json:"uint"`
+		Nil    []int          `json:"nil"`
+		NilMap map[string]int `json:"nil_map"`
+		Nested []struct {
+			Nil []string `json:"nil"`
+		} `json:"nested"`
+	}
+
+	value := options{
+		Big:   9007199254740993,
+		Small: 1,
+		Neg:   -9007199254740993,
+		Ubig:  18446744073709551615,
+		Int:   9007199254740993,
+		Uint:  9007199254740991,
+		Nested: []struct {
+			Nil []string `json:"nil"`
+		}{{}},
+	}
+
+	got, err := json.Marshal(toTree(reflect.ValueOf(value), treeOptions{int64AsString: true, nilSliceAsEmpty: true}, 0))
+	require.NoError(t, err)
+	assert.Equal(t, `{"big":"9007199254740993","small":1,"neg":"-9007199254740993","ubig":"18446744073709551615","int":"9007199254740993","uint":9007199254740991,"nil":[],"nil_map":null,"nested":[{"nil":[]}]}`, string(got))
+}
+
+func TestObject(t *testing.T) {
+	obj := newObject(0)
+	obj.Set("b", 1)
+	obj.Set("a", "<html>")
+	obj.Set("b", 2)
+
+	assert.Equal(t, []string{"b", "a"}, obj.Keys())
+	assert.Equal(t, 2, obj.Len())
+
+	value, ok := obj.Get("b")
+	assert.True(t, ok)
+	assert.Equal(t, 2, value)
+
+	// HTML escaping is up to the outer encoder
+	data, err := json.Marshal(obj)
+	require.NoError(t, err)
+	assert.Equal(t, `{"b":2,"a":"\u003chtml\u003e"}`, string(data))
+
+	data, err = encoderOptions{}.encode(obj)
+	require.NoError(t, err)
+	assert.Equal(t, "{\"b\":2,\"a\":\"<html>\"}\n", string(data))
+
+	assert.True(t, obj.Delete("b"))
+	assert.False(t, obj.Delete("b"))
+	assert.Equal(t, []string{"a"}, obj.Keys())
+}
@@ -0,0 +1,240 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Peter Vrba
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package jayson
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// VersionTransformer transforms rendered object of response type to the shape of older API version.
+// Object is passed as plain JSON values (map[string]any, []any and leaf values).
+type VersionTransformer func(context.Context, map[string]any) map[string]any
+
+// responseVersion is transformer registered for response type and version,
+// entries transformers are applied to entries of map response
+type responseVersion struct {
+	version   string
+	transform VersionTransformer
+	entries   bool
+}
+
+// extResponseVersion is an extension that adds version transformer to response writer
+func extResponseVersion(version string, transform VersionTransformer) Extension {
+	return extResponseWriter(func(rw *responseWriter) {
+		rw.versions = append(rw.versions, responseVersion{version: version, transform: transform, entries: rw.mapEntries})
+	})
+}
+
+// RegisterResponseVersion registers transformer that converts response type to the shape it had before given version.
+// Versions are compared as strings, so they should be sortable (e.g. dates "2024-01-01").
+// When client requests version, all transformers of newer versions are applied (newest first).
+func (j *jayson) RegisterResponseVersion(what any, version string, transform VersionTransformer) error {
+	switch {
+	case what == nil:
+		return fmt.Errorf("%w: response type is nil", ErrImproperlyConfigured)
+	case version == "":
+		return fmt.Errorf("%w: version of %T is empty", ErrImproperlyConfigured, what)
+	case transform == nil:
+		return fmt.Errorf("%w: transformer of %T version %s is nil", ErrImproperlyConfigured, what, version)
+	}
+	j.registryResponseVersions.Add(responseType(what), extResponseVersion(version, transform))
+	return nil
+}
+
+// ContextWithVersion returns context with requested API version, it takes precedence over request headers.
+func ContextWithVersion(ctx context.Context, version string) context.Context {
+	return context.WithValue(ctx, contextVersionKey, version)
+}
+
+// ContextVersionValue returns the API version stored in the context.
+func ContextVersionValue(ctx context.Context) (string, bool) {
+	version, ok := ctx.Value(contextVersionKey).(string)
+	return version, ok && version != ""
+}
+
+// requestVersion returns requested API version from context, header or media type parameter of Accept header
+func requestVersion(ctx context.Context, settings Settings) string {
+	if version, ok := ContextVersionValue(ctx); ok {
+		return version
+	}
+	r, ok := ContextRequestValue(ctx)
+	if !ok {
+		return ""
+	}
+	if settings.VersionHeader != "" {
+		if version := r.Header.Get(settings.VersionHeader); version != "" {
+			return version
+		}
+	}
+	if settings.VersionMediaTypeParameter != "" {
+		for _, accept := range r.Header.Values("Accept") {
+			for _, item := range strings.Split(accept, ",") {
+				if _, params, err := mime.ParseMediaType(item); err == nil && params[settings.VersionMediaTypeParameter] != "" {
+					return params[settings.VersionMediaTypeParameter]
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// versioned applies version transformers newer than requested version to rendered value (or to every item of slice),
+// transformers registered for element type of map are applied to its entries. It returns whether value was transformed.
+func (r *responseWriter) versioned(ctx context.Context, value any) (any, bool) {
+	if len(r.versions) == 0 {
+		return value, false
+	}
+
+	settings := ContextSettingsValue(ctx)
+	if settings.VersionHeader != "" {
+		addVary(r.Header(), settings.VersionHeader)
+	}
+	if settings.VersionMediaTypeParameter != "" {
+		addVary(r.Header(), "Accept")
+	}
+
+	requested := requestVersion(ctx, settings)
+	if requested == "" {
+		return value, false
+	}
+
+	var transforms []responseVersion
+	for _, version := range r.versions {
+		if version.version > requested {
+			transforms = append(transforms, version)
+		}
+	}
+	if len(transforms) == 0 {
+		return value, false
+	}
+	sort.SliceStable(transforms, func(i, k int) bool {
+		return transforms[i].version > transforms[k].version
+	})
+
+	var own, entries []responseVersion
+	for _, version := range transforms {
+		if version.entries {
+			entries = append(entries, version)
+		} else {
+			own = append(own, version)
+		}
+	}
+
+	tree := toTree(reflect.ValueOf(value), r.options.treeOptions(), 0)
+	switch items := tree.(type) {
+	case []any:
+		for i, item := range items {
+			items[i] = transformVersion(ctx, item, own)
+		}
+		return items, true
+	case *object:
+		for _, key := range items.Keys() {
+			item, _ := items.Get(key)
+			items.Set(key, transformVersion(ctx, item, entries))
+		}
+	}
+	return transformVersion(ctx, tree, own), true
+}
+
+// transformVersion applies transformers to tree object, order of keys that are kept is preserved
+func transformVersion(ctx context.Context, tree any, transforms []responseVersion) any {
+	obj, ok := tree.(*object)
+	if !ok || len(transforms) == 0 {
+		return tree
+	}
+	m, _ := plainValue(obj).(map[string]any)
+	for _, version := range transforms {
+		m = version.transform(ctx, m)
+	}
+	return treeValue(m, obj)
+}
+
+// plainValue converts tree to plain JSON values
+func plainValue(tree any) any {
+	switch value := tree.(type) {
+	case *object:
+		result := make(map[string]any, value.Len())
+		for _, key := range value.Keys() {
+			item, _ := value.Get(key)
+			result[key] = plainValue(item)
+		}
+		return result
+	case []any:
+		result := make([]any, len(value))
+		for i, item := range value {
+			result[i] = plainValue(item)
+		}
+		return result
+	default:
+		return value
+	}
+}
+
+// treeValue converts plain JSON values back to tree, keys present in original tree keep their order,
+// new keys are appended in sorted order
+func treeValue(plain any, original any) any {
+	switch value := plain.(type) {
+	case map[string]any:
+		orig, _ := original.(*object)
+		result := newObject(len(value))
+		if orig != nil {
+			for _, key := range orig.Keys() {
+				if item, ok := value[key]; ok {
+					origItem, _ := orig.Get(key)
+					result.Set(key, treeValue(item, origItem))
+				}
+			}
+		}
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			if _, ok := result.Get(key); !ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			result.Set(key, treeValue(value[key], nil))
+		}
+		return result
+	case []any:
+		orig, _ := original.([]any)
+		result := make([]any, len(value))
+		for i, item := range value {
+			var origItem any
+			if i < len(orig) {
+				origItem = orig[i]
+			}
+			result[i] = treeValue(item, origItem)
+		}
+		return result
+	default:
+		return value
+	}
+}
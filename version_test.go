@@ -0,0 +1,204 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Peter Vrba
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package jayson
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type versionedUser struct {
+	ID       int    `json:"id"`
+	FullName string `json:"full_name"`
+	Email    string `json:"email"`
+}
+
+// newVersionedJayson returns instance with two versions of versionedUser
+func newVersionedJayson(t *testing.T) Jayson {
+	j := New(DefaultSettings())
+	// email was added in 2024-06-01
+	assert.NoError(t, j.RegisterResponseVersion(&versionedUser{}, "2024-06-01", func(ctx context.Context, m map[string]any) map[string]any {
+		delete(m, "email")
+		return m
+	}))
+	// full_name was renamed from name in 2024-01-01
+	assert.NoError(t, j.RegisterResponseVersion(&versionedUser{}, "2024-01-01", func(ctx context.Context, m map[string]any) map[string]any {
+		m["name"] = m["full_name"]
+		delete(m, "full_name")
+		return m
+	}))
+	return j
+}
+
+func TestRegisterResponseVersion(t *testing.T) {
+	user := versionedUser{ID: 1, FullName: "Peter", Email: "peter@example.com"}
+
+	for _, item := range []struct {
+		name   string
+		ctx    func(r *http.Request) context.Context
+		what   any
+		expect string
+	}{
+		{
+			name:   "test latest",
+			ctx:    func(r *http.Request) context.Context { return ContextWithRequest(r.Context(), r) },
+			what:   user,
+			expect: `{"id":1,"full_name":"Peter","email":"peter@example.com"}`,
+		},
+		{
+			name: "test header",
+			ctx: func(r *http.Request) context.Context {
+				r.Header.Set("API-Version", "2024-03-01")
+				return ContextWithRequest(r.Context(), r)
+			},
+			what:   &user,
+			expect: `{"id":1,"full_name":"Peter"}`,
+		},
+		{
+			name: "test media type parameter",
+			ctx: func(r *http.Request) context.Context {
+				r.Header.Set("Accept", "text/html, application/json; version=2023-01-01")
+				return ContextWithRequest(r.Context(), r)
+			},
+			what:   []versionedUser{user},
+			expect: `[{"id":1,"name":"Peter"}]`,
+		},
+		{
+			name: "test map entries",
+			ctx: func(r *http.Request) context.Context {
+				r.Header.Set("API-Version", "2023-01-01")
+				return ContextWithRequest(r.Context(), r)
+			},
+			what:   map[string]*versionedUser{"peter": &user},
+			expect: `{"peter":{"id":1,"name":"Peter"}}`,
+		},
+		{
+			name: "test context",
+			ctx: func(r *http.Request) context.Context {
+				r.Header.Set("API-Version", "2030-01-01")
+				return ContextWithVersion(ContextWithRequest(r.Context(), r), "2023-01-01")
+			},
+			what:   ExtObjectUnwrap(user),
+			expect: `{"id":1,"name":"Peter"}`,
+		},
+		{
+			name: "test current version",
+			ctx: func(r *http.Request) context.Context {
+				return ContextWithVersion(r.Context(), "2024-06-01")
+			},
+			what:   user,
+			expect: `{"id":1,"full_name":"Peter","email":"peter@example.com"}`,
+		},
+	} {
+		t.Run(item.name, func(t *testing.T) {
+			j := newVersionedJayson(t)
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			rw := httptest.NewRecorder()
+			j.Response(item.ctx(r), rw, item.what)
+			assert.JSONEq(t, item.expect, rw.Body.String())
+			if _, ok := item.what.(Extension); !ok {
+				assert.Equal(t, item.expect+"\n", rw.Body.String())
+			}
+			assert.Equal(t, []string{"API-Version", "Accept"}, rw.Header().Values("Vary"))
+		})
+	}
+}
+
+func TestRegisterResponseVersionChild(t *testing.T) {
+	j := newVersionedJayson(t)
+
+	// re-registration of response type keeps versions
+	assert.NoError(t, j.RegisterResponse(versionedUser{}, ExtStatus(http.StatusAccepted)))
+
+	child := j.Child()
+	assert.NoError(t, child.RegisterResponseVersion(&versionedUser{}, "2025-01-01", func(ctx context.Context, m map[string]any) map[string]any {
+		m["legacy"] = true
+		return m
+	}))
+
+	rw := httptest.NewRecorder()
+	child.Response(ContextWithVersion(context.Background(), "2024-03-01"), rw, versionedUser{ID: 1, FullName: "Peter"})
+	assert.Equal(t, http.StatusAccepted, rw.Code)
+	assert.Equal(t, `{"id":1,"full_name":"Peter","legacy":true}`+"\n", rw.Body.String())
+
+	assert.ErrorIs(t, j.RegisterResponseVersion(versionedUser{}, "", nil), ErrImproperlyConfigured)
+	assert.EqualError(t, j.RegisterResponseVersion(nil, "2025-01-01", nil), "jayson: improperly configured: response type is nil")
+	assert.EqualError(t, j.RegisterResponseVersion(versionedUser{}, "", nil), "jayson: improperly configured: version of jayson.versionedUser is empty")
+	assert.EqualError(t, j.RegisterResponseVersion(versionedUser{}, "2025-01-01", nil), "jayson: improperly configured: transformer of jayson.versionedUser version 2025-01-01 is nil")
+}
+
+func TestRegisterResponseVersionFields(t *testing.T) {
+	user := versionedUser{ID: 1, FullName: "Peter", Email: "peter@example.com"}
+
+	for _, item := range []struct {
+		name   string
+		query  string
+		what   any
+		status int
+		expect string
+	}{
+		{"test old name", "fields=name", user, http.StatusOK, `{"name":"Peter"}`},
+		{"test old name in slice", "fields=id,name", []versionedUser{user}, http.StatusOK, `[{"id":1,"name":"Peter"}]`},
+		{"test old name in extension", "fields=name", ExtObjectUnwrap(user), http.StatusOK, `{"name":"Peter"}`},
+		{"test new name", "fields=full_name", user, http.StatusBadRequest, ""},
+		{"test removed field", "fields=email", ExtObjectUnwrap(user), http.StatusBadRequest, ""},
+	} {
+		t.Run(item.name, func(t *testing.T) {
+			j := newVersionedJayson(t)
+			r := httptest.NewRequest(http.MethodGet, "/?"+item.query, nil)
+			r.Header.Set("API-Version", "2023-01-01")
+			ctx := ContextWithSettings(ContextWithRequest(r.Context(), r), func(s *Settings) {
+				s.FieldsQueryParameter = "fields"
+			})
+			rw := httptest.NewRecorder()
+			j.Response(ctx, rw, item.what)
+			assert.Equal(t, item.status, rw.Code)
+			if item.expect != "" {
+				assert.JSONEq(t, item.expect, rw.Body.String())
+			}
+		})
+	}
+}
+
+func TestRegisterResponseVersionMap(t *testing.T) {
+	type settingsMap map[string]any
+
+	j := newVersionedJayson(t)
+	// map type registered itself is transformed as a whole
+	assert.NoError(t, j.RegisterResponseVersion(settingsMap{}, "2024-01-01", func(ctx context.Context, m map[string]any) map[string]any {
+		m["legacy"] = true
+		return m
+	}))
+
+	ctx := ContextWithVersion(context.Background(), "2023-01-01")
+	assert.JSONEq(t, `{"theme":"dark","legacy":true}`, string(j.RenderResponse(ctx, settingsMap{"theme": "dark"}).Body))
+	assert.JSONEq(t,
+		`{"peter":{"id":1,"name":"Peter"}}`,
+		string(j.RenderResponse(ctx, map[string]versionedUser{"peter": {ID: 1, FullName: "Peter"}}).Body),
+	)
+}
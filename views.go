@@ -0,0 +1,138 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Peter Vrba
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package jayson
+
+import (
+	"reflect"
+	"slices"
+	"sync"
+)
+
+var (
+	// viewTypeCache caches view metadata of types
+	viewTypeCache sync.Map
+)
+
+// viewTypeInfo is cached view metadata of type
+type viewTypeInfo struct {
+	// views is set when type (or any type it contains) has fields restricted to views
+	views bool
+	// dynamic is set when type contains interfaces, so values need to be inspected
+	dynamic bool
+}
+
+// visible returns whether field is visible in any of given views (field without views is visible in all views)
+func (f structField) visible(views []string) bool {
+	if len(f.jayson.views) == 0 {
+		return true
+	}
+	for _, view := range views {
+		if slices.Contains(f.jayson.views, view) {
+			return true
+		}
+	}
+	return false
+}
+
+// cachedViewTypeInfo returns view metadata of type
+func cachedViewTypeInfo(typ reflect.Type) viewTypeInfo {
+	if info, ok := viewTypeCache.Load(typ); ok {
+		return info.(viewTypeInfo)
+	}
+	info := walkViewTypeInfo(typ, make(map[reflect.Type]bool))
+	viewTypeCache.Store(typ, info)
+	return info
+}
+
+// walkViewTypeInfo inspects type recursively
+func walkViewTypeInfo(typ reflect.Type, visited map[reflect.Type]bool) (result viewTypeInfo) {
+	if visited[typ] || isMarshalerType(typ) {
+		return result
+	}
+	visited[typ] = true
+
+	switch typ.Kind() {
+	case reflect.Interface:
+		result.dynamic = true
+	case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+		result = walkViewTypeInfo(typ.Elem(), visited)
+	case reflect.Struct:
+		for _, field := range cachedStructInfo(typ).fields {
+			if len(field.jayson.views) > 0 {
+				result.views = true
+			}
+			sub := walkViewTypeInfo(typ.FieldByIndex(field.index).Type, visited)
+			result.views = result.views || sub.views
+			result.dynamic = result.dynamic || sub.dynamic
+		}
+	default:
+		// no-op
+	}
+
+	return result
+}
+
+// hasViews returns whether value contains fields restricted to views (so it needs to be filtered)
+func hasViews(v reflect.Value, depth int) bool {
+	if !v.IsValid() || depth > maxTreeDepth {
+		return false
+	}
+
+	info := cachedViewTypeInfo(v.Type())
+	if info.views {
+		return true
+	}
+	if !info.dynamic {
+		return false
+	}
+
+	// type contains interfaces, so we need to inspect actual values
+	switch v.Kind() {
+	case reflect.Interface, reflect.Pointer:
+		return !v.IsNil() && hasViews(v.Elem(), depth+1)
+	case reflect.Struct:
+		for _, field := range cachedStructInfo(v.Type()).fields {
+			if fv, ok := fieldByIndex(v, field.index); ok && hasViews(fv, depth+1) {
+				return true
+			}
+		}
+	case reflect.Map:
+		for iter := v.MapRange(); iter.Next(); {
+			if hasViews(iter.Value(), depth+1) {
+				return true
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if hasViews(v.Index(i), depth+1) {
+				return true
+			}
+		}
+	default:
+		// no-op
+	}
+
+	return false
+}
@@ -0,0 +1,127 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2025 Peter Vrba
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package jayson
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type viewProfile struct {
+	Bio   string `json:"bio"`
+	Phone string `json:"phone" jayson:"view=admin|support"`
+}
+
+type viewUser struct {
+	ID      int          `json:"id"`
+	Email   string       `json:"email" jayson:"view=admin"`
+	Profile viewProfile  `json:"profile"`
+	Friends []viewUser   `json:"friends,omitempty"`
+	Meta    any          `json:"meta,omitempty"`
+	Parent  *viewProfile `json:"parent,omitempty"`
+}
+
+type viewPlain struct {
+	ID   int `json:"id"`
+	Meta any `json:"meta"`
+}
+
+func TestHasViews(t *testing.T) {
+	assert.True(t, hasViews(reflect.ValueOf(viewUser{}), 0))
+	assert.True(t, hasViews(reflect.ValueOf([]*viewProfile{}), 0))
+	assert.False(t, hasViews(reflect.ValueOf(viewPlain{}), 0))
+	assert.False(t, hasViews(reflect.ValueOf(map[string]any{"id": 1}), 0))
+	assert.True(t, hasViews(reflect.ValueOf(map[string]any{"user": viewProfile{}}), 0))
+	assert.True(t, hasViews(reflect.ValueOf(viewPlain{Meta: []any{viewProfile{}}}), 0))
+	assert.False(t, hasViews(reflect.Value{}, 0))
+}
+
+func TestViews(t *testing.T) {
+	value := viewUser{
+		ID:      1,
+		Email:   "phonkee@phonkee.eu",
+		Profile: viewProfile{Bio: "gopher", Phone: "123"},
+		Friends: []viewUser{{ID: 2, Email: "friend@phonkee.eu"}},
+		Meta:    map[string]any{"profile": viewProfile{Bio: "meta", Phone: "456"}},
+	}
+
+	render := func(ctx context.Context, what any) string {
+		return string(New(DefaultSettings()).RenderResponse(ctx, what).Body)
+	}
+
+	t.Run("test public", func(t *testing.T) {
+		assert.JSONEq(t,
+			`{"id":1,"profile":{"bio":"gopher"},"friends":[{"id":2,"profile":{"bio":""}}],"meta":{"profile":{"bio":"meta"}}}`,
+			render(context.Background(), value),
+		)
+	})
+
+	t.Run("test admin", func(t *testing.T) {
+		assert.JSONEq(t,
+			`{"id":1,"email":"phonkee@phonkee.eu","profile":{"bio":"gopher","phone":"123"},"friends":[{"id":2,"email":"friend@phonkee.eu","profile":{"bio":"","phone":""}}],"meta":{"profile":{"bio":"meta","phone":"456"}}}`,
+			render(ContextWithView(context.Background(), "admin"), value),
+		)
+	})
+
+	t.Run("test support", func(t *testing.T) {
+		assert.JSONEq(t,
+			`{"id":1,"profile":{"bio":"gopher","phone":"123"}}`,
+			render(ContextWithView(context.Background(), "guest", "support"), viewUser{ID: 1, Profile: viewProfile{Bio: "gopher", Phone: "123"}}),
+		)
+	})
+
+	t.Run("test object unwrap", func(t *testing.T) {
+		assert.JSONEq(t,
+			`{"id":1,"profile":{"bio":"gopher"},"extra":true}`,
+			render(context.Background(), ExtChain(ExtObjectUnwrap(viewUser{ID: 1, Email: "a@b.c", Profile: viewProfile{Bio: "gopher", Phone: "1"}}), ExtObjectKeyValue("extra", true))),
+		)
+		assert.JSONEq(t,
+			`{"id":1,"email":"a@b.c","profile":{"bio":"gopher","phone":"1"}}`,
+			render(ContextWithView(context.Background(), "admin"), ExtObjectUnwrap(&viewUser{ID: 1, Email: "a@b.c", Profile: viewProfile{Bio: "gopher", Phone: "1"}})),
+		)
+	})
+
+	t.Run("test error fields", func(t *testing.T) {
+		rendered := New(DefaultSettings()).RenderError(context.Background(), NewError("invalid").Status(http.StatusBadRequest).Field("profile", viewProfile{Bio: "gopher", Phone: "1"}))
+		assert.NotContains(t, string(rendered.Body), "phone")
+	})
+
+	t.Run("test sparse fieldset", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/?fields=email", nil)
+		ctx := ContextWithRequest(r.Context(), r)
+
+		rendered := New(DefaultSettings()).RenderResponse(ctx, value, ExtFieldsQuery("fields"))
+		assert.Equal(t, http.StatusBadRequest, rendered.Status)
+
+		rendered = New(DefaultSettings()).RenderResponse(ContextWithView(ctx, "admin"), value, ExtFieldsQuery("fields"))
+		assert.Equal(t, http.StatusOK, rendered.Status)
+		assert.JSONEq(t, `{"email":"phonkee@phonkee.eu"}`, string(rendered.Body))
+	})
+}